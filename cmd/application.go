@@ -0,0 +1,60 @@
+// Copyright © 2020 Developer <developer@vamp.io>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+var applicationCmd = &cobra.Command{
+	Use:   "application",
+	Short: "Application operations",
+	Long: AddAppName(`Application operations
+    Example:
+    $AppName application add-namespace <application_id> <namespace> --cluster <cluster_id>`),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return errors.New("An operation expected")
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(applicationCmd)
+}
+
+// getApplicationNamespaceArgs - gets application id and namespace from arguments
+func getApplicationNamespaceArgs(args []string) (uint64, string, error) {
+	if len(args) < 2 {
+		return 0, "", fmt.Errorf("Not enough arguments - application id and namespace needed")
+	}
+	applicationIDString := args[0]
+
+	applicationID, err := strconv.ParseUint(applicationIDString, 10, 64)
+	if err != nil {
+		return 0, "", fmt.Errorf("Application id '%s' must be a natural number", applicationIDString)
+	}
+	return applicationID, args[1], nil
+}
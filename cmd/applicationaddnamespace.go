@@ -0,0 +1,71 @@
+// Copyright © 2020 Developer <developer@vamp.io>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/magneticio/forklift/core"
+	"github.com/magneticio/forklift/logging"
+	"github.com/spf13/cobra"
+)
+
+var applicationAddNamespaceCmd = &cobra.Command{
+	Use:   "add-namespace",
+	Short: "Add a namespace to an application",
+	Long: AddAppName(`Add a namespace to an existing application
+    Usage:
+    $AppName application add-namespace <application_id> <namespace> --cluster <cluster_id> [--force]
+    Namespace used by another application is reassigned only with --force flag.`),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		applicationID, namespace, err := getApplicationNamespaceArgs(args)
+		if err != nil {
+			return err
+		}
+
+		logging.Info("Adding namespace '%s' to application '%d'\n", namespace, applicationID)
+		core, err := core.NewCore(Config)
+		if err != nil {
+			return err
+		}
+
+		displacedApplications, err := core.AddApplicationNamespace(applicationID, namespace, forceNamespace)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Namespace '%s' has been added to application '%d'\n", namespace, applicationID)
+
+		for _, displacedApplication := range displacedApplications {
+			printDisplacedApplicationWarning(displacedApplication)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	applicationCmd.AddCommand(applicationAddNamespaceCmd)
+
+	applicationAddNamespaceCmd.Flags().BoolVar(&forceNamespace, "force", false, "Reassign namespace used by another application")
+}
@@ -0,0 +1,64 @@
+// Copyright © 2020 Developer <developer@vamp.io>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/magneticio/forklift/core"
+	"github.com/magneticio/forklift/logging"
+	"github.com/spf13/cobra"
+)
+
+var applicationRemoveNamespaceCmd = &cobra.Command{
+	Use:   "remove-namespace",
+	Short: "Remove a namespace from an application",
+	Long: AddAppName(`Remove a namespace from an existing application
+    Usage:
+    $AppName application remove-namespace <application_id> <namespace> --cluster <cluster_id>
+    The last namespace of an application cannot be removed, delete the application instead.`),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		applicationID, namespace, err := getApplicationNamespaceArgs(args)
+		if err != nil {
+			return err
+		}
+
+		logging.Info("Removing namespace '%s' from application '%d'\n", namespace, applicationID)
+		core, err := core.NewCore(Config)
+		if err != nil {
+			return err
+		}
+
+		if err := core.RemoveApplicationNamespace(applicationID, namespace); err != nil {
+			return err
+		}
+
+		fmt.Printf("Namespace '%s' has been removed from application '%d'\n", namespace, applicationID)
+
+		return nil
+	},
+}
+
+func init() {
+	applicationCmd.AddCommand(applicationRemoveNamespaceCmd)
+}
@@ -22,10 +22,16 @@ package cmd
 
 import (
 	"errors"
+	"fmt"
 
+	"github.com/magneticio/forklift/models"
+	"github.com/magneticio/forklift/util"
 	"github.com/spf13/cobra"
 )
 
+var cascadeDelete bool
+var assumeYes bool
+
 // deleteCmd represents the delete command
 var deleteCmd = &cobra.Command{
 	Use:   "delete",
@@ -43,3 +49,13 @@ var deleteCmd = &cobra.Command{
 func init() {
 	rootCmd.AddCommand(deleteCmd)
 }
+
+// confirmKeyTreeDeletion - prints keys which are going to be deleted and asks for confirmation unless --yes is set
+func confirmKeyTreeDeletion(keyTree models.KeyTree, question string) (bool, error) {
+	fmt.Println("The following keys will be deleted:")
+	fmt.Print(keyTree.String())
+	if assumeYes {
+		return true, nil
+	}
+	return util.Confirm(question)
+}
@@ -34,7 +34,9 @@ var deleteApplicationCmd = &cobra.Command{
 	Short: "Delete existing application",
 	Long: AddAppName(`Delete existing application
     Usage:
-    $AppName delete application <application_id> --cluster <cluster_id>`),
+    $AppName delete application <application_id> --cluster <cluster_id> [--cascade [--yes]]
+    With --cascade all service configs and release plans of the application are deleted as well,
+    the keys are printed and confirmation is required unless --yes is provided.`),
 	SilenceUsage:  true,
 	SilenceErrors: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -53,9 +55,30 @@ var deleteApplicationCmd = &cobra.Command{
 			return err
 		}
 
-		err = core.DeleteApplication(applicationID)
-		if err != nil {
-			return err
+		if cascadeDelete {
+			keyTree, err := core.GetApplicationKeyTree(applicationID)
+			if err != nil {
+				return err
+			}
+			confirmed, err := confirmKeyTreeDeletion(*keyTree, fmt.Sprintf("Delete application '%d' with all its keys?", applicationID))
+			if err != nil {
+				return err
+			}
+			if !confirmed {
+				fmt.Printf("Deletion of application '%d' has been cancelled\n", applicationID)
+				return nil
+			}
+			if err := core.DeleteApplication(applicationID); err != nil {
+				return err
+			}
+			if err := core.DeleteKeyTree(*keyTree); err != nil {
+				return err
+			}
+		} else {
+			err = core.DeleteApplication(applicationID)
+			if err != nil {
+				return err
+			}
 		}
 
 		fmt.Printf("Application '%d' has been deleted\n", applicationID)
@@ -66,4 +89,7 @@ var deleteApplicationCmd = &cobra.Command{
 
 func init() {
 	deleteCmd.AddCommand(deleteApplicationCmd)
+
+	deleteApplicationCmd.Flags().BoolVar(&cascadeDelete, "cascade", false, "Delete service configs and release plans of the application as well")
+	deleteApplicationCmd.Flags().BoolVarP(&assumeYes, "yes", "y", false, "Do not ask for confirmation of cascading delete")
 }
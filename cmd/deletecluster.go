@@ -34,7 +34,9 @@ var deleteClusterCmd = &cobra.Command{
 	Short: "Delete existing cluster",
 	Long: AddAppName(`Delete existing cluster
     Usage:
-    $AppName delete cluster <cluster_id>`),
+    $AppName delete cluster <cluster_id> [--cascade [--yes]]
+    With --cascade all service configs and release plans of the cluster are deleted as well,
+    the keys are printed and confirmation is required unless --yes is provided.`),
 	SilenceUsage:  true,
 	SilenceErrors: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -53,9 +55,27 @@ var deleteClusterCmd = &cobra.Command{
 			return err
 		}
 
-		err = core.DeleteReleaseAgentConfig(clusterID)
-		if err != nil {
-			return err
+		if cascadeDelete {
+			keyTree, err := core.GetClusterKeyTree(clusterID)
+			if err != nil {
+				return err
+			}
+			confirmed, err := confirmKeyTreeDeletion(*keyTree, fmt.Sprintf("Delete cluster '%d' with all its keys?", clusterID))
+			if err != nil {
+				return err
+			}
+			if !confirmed {
+				fmt.Printf("Deletion of cluster '%d' has been cancelled\n", clusterID)
+				return nil
+			}
+			if err := core.DeleteKeyTree(*keyTree); err != nil {
+				return err
+			}
+		} else {
+			err = core.DeleteReleaseAgentConfig(clusterID)
+			if err != nil {
+				return err
+			}
 		}
 
 		fmt.Printf("Cluster '%d' has been deleted\n", clusterID)
@@ -66,4 +86,7 @@ var deleteClusterCmd = &cobra.Command{
 
 func init() {
 	deleteCmd.AddCommand(deleteClusterCmd)
+
+	deleteClusterCmd.Flags().BoolVar(&cascadeDelete, "cascade", false, "Delete service configs and release plans of the cluster as well")
+	deleteClusterCmd.Flags().BoolVarP(&assumeYes, "yes", "y", false, "Do not ask for confirmation of cascading delete")
 }
@@ -22,40 +22,38 @@ package cmd
 
 import (
 	"fmt"
-	"strconv"
 
 	"github.com/magneticio/forklift/core"
 	"github.com/magneticio/forklift/logging"
 	"github.com/spf13/cobra"
 )
 
+var forceDelete bool
+
 var deletePolicyCmd = &cobra.Command{
 	Use:   "policy",
 	Short: "Delete existing policy",
 	Long: AddAppName(`Delete existing policy
     Usage:
-    $AppName delete policy <policy_id>`),
+    $AppName delete policy <policy_id> [--force]
+    $AppName delete policy --name <policy_name> [--force]
+    Policy referenced by any service config is deleted only with --force flag.`),
 	SilenceUsage:  true,
 	SilenceErrors: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		if len(args) < 1 {
-			return fmt.Errorf("Not enough arguments - policy id needed")
-		}
-		policyIDString := args[0]
-
-		policyID, err := strconv.ParseUint(policyIDString, 10, 64)
+		core, err := core.NewCore(Config)
 		if err != nil {
-			return fmt.Errorf("Policy id '%s' must be a natural number", policyIDString)
+			return err
 		}
 
-		logging.Info("Deleting policy '%d'\n", policyID)
-
-		core, err := core.NewCore(Config)
+		policyID, err := getPolicyID(core, args, policyName)
 		if err != nil {
 			return err
 		}
 
-		err = core.DeletePolicy(policyID)
+		logging.Info("Deleting policy '%d'\n", policyID)
+
+		err = core.DeletePolicy(policyID, forceDelete)
 		if err != nil {
 			return err
 		}
@@ -68,4 +66,7 @@ var deletePolicyCmd = &cobra.Command{
 
 func init() {
 	deleteCmd.AddCommand(deletePolicyCmd)
+
+	deletePolicyCmd.Flags().StringVar(&policyName, "name", "", "Name of the policy")
+	deletePolicyCmd.Flags().BoolVar(&forceDelete, "force", false, "Delete policy even if it is referenced by service configs")
 }
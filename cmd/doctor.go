@@ -0,0 +1,104 @@
+// Copyright © 2020 Developer <developer@vamp.io>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/magneticio/forklift/core"
+	"github.com/magneticio/forklift/logging"
+	"github.com/spf13/cobra"
+)
+
+var fixIssues bool
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check the project for orphaned and invalid keys",
+	Long: AddAppName(`Check the project for orphaned and invalid keys
+    Reports cluster directories without Release Agent config, service configs of applications not mapped to any namespace,
+    release plans of missing service configs and service configs which do not pass validation.
+    With --fix the keys of each orphaned issue are printed and deleted after confirmation unless --yes is provided.
+    Usage:
+    $AppName doctor [--fix [--yes]]`),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logging.Info("Checking project\n")
+		core, err := core.NewCore(Config)
+		if err != nil {
+			return err
+		}
+
+		issues, err := core.CheckProject()
+		if err != nil {
+			return err
+		}
+		if len(issues) == 0 {
+			fmt.Printf("No problems found\n")
+			return nil
+		}
+
+		for _, issue := range issues {
+			fmt.Println(issue)
+		}
+		if !fixIssues {
+			return fmt.Errorf("Found %d problem(s) in project", len(issues))
+		}
+
+		unfixed := 0
+		for _, issue := range issues {
+			if !issue.Fixable {
+				fmt.Printf("Skipping %s '%s', it has to be fixed manually\n", issue.Kind, issue.Key)
+				unfixed++
+				continue
+			}
+			keyTree, err := core.GetProjectIssueKeyTree(issue)
+			if err != nil {
+				return err
+			}
+			confirmed, err := confirmKeyTreeDeletion(*keyTree, fmt.Sprintf("Delete %s '%s'?", issue.Kind, issue.Key))
+			if err != nil {
+				return err
+			}
+			if !confirmed {
+				unfixed++
+				continue
+			}
+			if err := core.DeleteKeyTree(*keyTree); err != nil {
+				return err
+			}
+			fmt.Printf("Deleted %s '%s'\n", issue.Kind, issue.Key)
+		}
+
+		if unfixed > 0 {
+			return fmt.Errorf("%d of %d problem(s) left unfixed", unfixed, len(issues))
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+
+	doctorCmd.Flags().BoolVar(&fixIssues, "fix", false, "Delete orphaned keys")
+	doctorCmd.Flags().BoolVarP(&assumeYes, "yes", "y", false, "Do not ask for confirmation of each fix")
+}
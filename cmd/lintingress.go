@@ -0,0 +1,66 @@
+// Copyright © 2020 Developer <developer@vamp.io>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/magneticio/forklift/core"
+	"github.com/magneticio/forklift/logging"
+	"github.com/spf13/cobra"
+)
+
+var lintIngressCmd = &cobra.Command{
+	Use:   "ingress",
+	Short: "Check ingress rules of a cluster for conflicts",
+	Long: AddAppName(`Check ingress rules of a cluster for conflicts
+    Every domain and path pair claimed by more than one service of the cluster is reported.
+    Usage:
+    $AppName lint ingress --cluster <cluster_id>`),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logging.Info("Linting ingress rules\n")
+		core, err := core.NewCore(Config)
+		if err != nil {
+			return err
+		}
+
+		conflicts, err := core.FindIngressConflicts()
+		if err != nil {
+			return err
+		}
+		if len(conflicts) == 0 {
+			fmt.Printf("No ingress conflicts found\n")
+			return nil
+		}
+
+		for _, conflict := range conflicts {
+			fmt.Println(conflict)
+		}
+
+		return fmt.Errorf("Found %d ingress conflict(s)", len(conflicts))
+	},
+}
+
+func init() {
+	lintCmd.AddCommand(lintIngressCmd)
+}
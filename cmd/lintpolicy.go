@@ -0,0 +1,68 @@
+// Copyright © 2020 Developer <developer@vamp.io>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/magneticio/forklift/logging"
+	"github.com/magneticio/forklift/models"
+	"github.com/spf13/cobra"
+)
+
+var lintPolicyCmd = &cobra.Command{
+	Use:   "policy",
+	Short: "Check a policy definition for problems",
+	Long: AddAppName(`Check a policy definition for problems without connecting to the key value store
+    All problems are reported at once together with JSON pointers to their locations.
+    Usage:
+    $AppName lint policy --file <policy_file_path>`),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logging.Info("Linting policy '%s'\n", configPath)
+
+		policyText, err := readPolicyFile(configPath, configFileType, cmd.Flags().Changed("input"))
+		if err != nil {
+			return err
+		}
+
+		issues := models.LintPolicy(policyText)
+		if len(issues) == 0 {
+			fmt.Printf("Policy is valid\n")
+			return nil
+		}
+
+		for _, issue := range issues {
+			fmt.Println(issue)
+		}
+
+		return fmt.Errorf("Found %d problem(s) in policy", len(issues))
+	},
+}
+
+func init() {
+	lintCmd.AddCommand(lintPolicyCmd)
+
+	lintPolicyCmd.Flags().StringVarP(&configPath, "file", "f", "", "Policy configuration file path")
+	lintPolicyCmd.MarkFlagRequired("file")
+	lintPolicyCmd.Flags().StringVarP(&configFileType, "input", "i", "json", "Policy configuration file type yaml or json, detected from file extension by default")
+}
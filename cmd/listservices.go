@@ -25,19 +25,35 @@ import (
 
 	"github.com/magneticio/forklift/core"
 	"github.com/magneticio/forklift/logging"
+	"github.com/magneticio/forklift/models"
 	"github.com/spf13/cobra"
 	yaml "gopkg.in/yaml.v3"
 )
 
+var serviceLabelSelector string
+var serviceSortBy string
+var serviceSortReverse bool
+
 var listServicesCmd = &cobra.Command{
 	Use:   "services",
 	Short: "List existing services",
 	Long: AddAppName(`List existing services
     Usage:
-    $AppName list services --cluster <cluster_id> --application <application_id>`),
+    $AppName list services --cluster <cluster_id> --application <application_id> [-l <label_selector>] [--sort-by <key>] [--reverse]
+    Label selector is a comma separated list of requirements like app=nginx, tier!=cache, canary or !legacy.
+    Services can be sorted by id, namespace or version-selector.
+    Services whose config cannot be read are listed with an error and kept by any selector.`),
 	SilenceUsage:  true,
 	SilenceErrors: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		selector, err := models.ParseLabelSelector(serviceLabelSelector)
+		if err != nil {
+			return err
+		}
+		if err := models.CheckServiceSortKey(serviceSortBy); err != nil {
+			return err
+		}
+
 		logging.Info("Listing services")
 		core, err := core.NewCore(Config)
 		if err != nil {
@@ -49,6 +65,11 @@ var listServicesCmd = &cobra.Command{
 			return err
 		}
 
+		services = models.FilterServiceViews(services, selector)
+		if err := models.SortServiceViews(services, serviceSortBy, serviceSortReverse); err != nil {
+			return err
+		}
+
 		output, err := yaml.Marshal(services)
 		if err != nil {
 			return err
@@ -65,4 +86,7 @@ func init() {
 
 	listServicesCmd.Flags().Uint64VarP(&applicationID, "application", "a", 0, "ID of the application")
 	listServicesCmd.MarkFlagRequired("application")
+	listServicesCmd.Flags().StringVarP(&serviceLabelSelector, "selector", "l", "", "Label selector, e.g. app=nginx,tier!=cache")
+	listServicesCmd.Flags().StringVar(&serviceSortBy, "sort-by", models.ServiceSortByID, "Sort services by id, namespace or version-selector")
+	listServicesCmd.Flags().BoolVar(&serviceSortReverse, "reverse", false, "Reverse sort order")
 }
@@ -0,0 +1,83 @@
+// Copyright © 2020 Developer <developer@vamp.io>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/magneticio/forklift/core"
+	"github.com/magneticio/forklift/logging"
+	"github.com/magneticio/forklift/util"
+	"github.com/spf13/cobra"
+)
+
+var patchType string
+var patchText string
+
+var patchServiceCmd = &cobra.Command{
+	Use:   "service",
+	Short: "Patch existing service",
+	Long: AddAppName(`Patch existing service config with a JSON merge patch (RFC 7386) or a JSON patch (RFC 6902)
+    Patched service config is validated like a put one and the changed lines are printed.
+    Usage:
+    $AppName patch service --cluster <cluster_id> --application <application_id> --service <service_id> --patch '{"k8s_labels":{"tier":"web"}}'
+    $AppName patch service --cluster <cluster_id> --application <application_id> --service <service_id> --type json --patch '[{"op":"replace","path":"/ingress_rules/0/port","value":8080}]'`),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logging.Info("Patching service '%d' of application '%d'\n", serviceID, applicationID)
+		core, err := core.NewCore(Config)
+		if err != nil {
+			return err
+		}
+
+		previous, patched, err := core.PatchServiceConfig(applicationID, serviceID, patchType, patchText, allowSharedIngress)
+		if err != nil {
+			return err
+		}
+
+		if previous == patched {
+			fmt.Printf("Service '%d' has not changed\n", serviceID)
+			return nil
+		}
+
+		fmt.Println(strings.Join(util.DiffLines(previous, patched), "\n"))
+		fmt.Printf("Service '%d' has been patched\n", serviceID)
+
+		return nil
+	},
+}
+
+func init() {
+	patchCmd.AddCommand(patchServiceCmd)
+
+	patchServiceCmd.Flags().Uint64VarP(&applicationID, "application", "a", 0, "ID of the application")
+	patchServiceCmd.MarkFlagRequired("application")
+
+	patchServiceCmd.Flags().Uint64VarP(&serviceID, "service", "s", 0, "ID of the service")
+	patchServiceCmd.MarkFlagRequired("service")
+
+	patchServiceCmd.Flags().StringVar(&patchText, "patch", "", "Patch document, JSON object for merge type or JSON array of operations for json type")
+	patchServiceCmd.MarkFlagRequired("patch")
+	patchServiceCmd.Flags().StringVar(&patchType, "type", util.MergePatchType, "Patch type merge or json")
+	patchServiceCmd.Flags().BoolVar(&allowSharedIngress, "allow-shared", false, "Allow ingress domain and path already used by another service of the cluster")
+}
@@ -0,0 +1,120 @@
+// Copyright © 2020 Developer <developer@vamp.io>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/magneticio/forklift/core"
+	"github.com/magneticio/forklift/models"
+	"github.com/magneticio/forklift/util"
+	"github.com/spf13/cobra"
+)
+
+var policyName string
+var revealPolicyHeaders bool
+var policyOutputFormat string
+
+var policyCmd = &cobra.Command{
+	Use:   "policy",
+	Short: "Policy operations",
+	Long: AddAppName(`Policy operations
+    Example:
+    $AppName policy usages <policy_id>`),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return errors.New("An operation expected")
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(policyCmd)
+}
+
+// getPolicyID - gets policy id from the first argument or from the policy name if it is provided instead
+func getPolicyID(core *core.Core, args []string, policyName string) (uint64, error) {
+	if policyName != "" {
+		if len(args) > 0 {
+			return 0, fmt.Errorf("Either policy id or policy name can be provided")
+		}
+		return core.GetPolicyIDByName(policyName)
+	}
+	if len(args) < 1 {
+		return 0, fmt.Errorf("Not enough arguments - policy id needed")
+	}
+	policyIDString := args[0]
+
+	policyID, err := strconv.ParseUint(policyIDString, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("Policy id '%s' must be a natural number", policyIDString)
+	}
+	return policyID, nil
+}
+
+// readPolicyFile - reads policy definition from a file and converts it to json
+// yaml files are detected from the file extension unless input format is set explicitly
+func readPolicyFile(policyPath string, inputFormat string, inputFormatSet bool) (string, error) {
+	policyBytes, err := util.UseSourceUrl(policyPath)
+	if err != nil {
+		return "", err
+	}
+
+	if !inputFormatSet && (strings.HasSuffix(policyPath, ".yaml") || strings.HasSuffix(policyPath, ".yml")) {
+		inputFormat = "yaml"
+	}
+
+	if inputFormat == "dsl" {
+		policy, err := models.CompilePolicyDSL(policyBytes)
+		if err != nil {
+			return "", err
+		}
+		return models.MarshalPolicyDocument(*policy)
+	}
+
+	policyJSON, err := util.Convert(inputFormat, "json", policyBytes)
+	if err != nil {
+		return "", err
+	}
+	return string(policyJSON), nil
+}
+
+// maskPolicyText - masks values of hook headers in policy printed by a command unless --reveal is set
+func maskPolicyText(policyText string) (string, error) {
+	if revealPolicyHeaders {
+		return policyText, nil
+	}
+	return models.MaskPolicyHeaders(policyText)
+}
+
+// formatPolicy - converts policy json to json, yaml or dsl output
+func formatPolicy(policyText string, outputFormat string) (string, error) {
+	switch outputFormat {
+	case "json", "yaml":
+		return util.Convert("json", outputFormat, policyText)
+	case "dsl":
+		return models.ConvertPolicyToDSL(policyText)
+	}
+	return "", fmt.Errorf("Unsupported output format '%s', expected json, yaml or dsl", outputFormat)
+}
@@ -0,0 +1,101 @@
+// Copyright © 2020 Developer <developer@vamp.io>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/magneticio/forklift/logging"
+	"github.com/magneticio/forklift/models"
+	"github.com/magneticio/forklift/util"
+	"github.com/spf13/cobra"
+)
+
+var policyCompileCmd = &cobra.Command{
+	Use:   "compile",
+	Short: "Compile a policy written in the policy DSL or YAML",
+	Long: AddAppName(`Compile a policy written in the policy DSL or YAML to the policy JSON format
+    Every line of the DSL is a statement, for example:
+      metric Health TimeBased from k8s-deployment-health
+      step 10% for 1m when Health >= 1 within 5m budget 70 after 1m
+    YAML has the structure of the policy JSON format, unknown fields are rejected.
+    Input is read as YAML for .yaml and .yml files and as DSL otherwise unless --input is provided.
+    Usage:
+    $AppName policy compile --file <policy_file_path> [--input dsl|yaml] [--output json|yaml]`),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logging.Info("Compiling policy '%s'\n", configPath)
+
+		inputFormat := configFileType
+		if !cmd.Flags().Changed("input") {
+			inputFormat = "dsl"
+			if strings.HasSuffix(configPath, ".yaml") || strings.HasSuffix(configPath, ".yml") {
+				inputFormat = "yaml"
+			}
+		}
+		var policyText string
+		var err error
+		switch inputFormat {
+		case "dsl":
+			policyText, err = readPolicyFile(configPath, "dsl", true)
+		case "yaml":
+			policyText, err = compilePolicyYAMLFile(configPath)
+		default:
+			return fmt.Errorf("Unknown input format '%s', expected dsl or yaml", inputFormat)
+		}
+		if err != nil {
+			return err
+		}
+
+		formattedPolicyText, err := formatPolicy(policyText, policyOutputFormat)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(formattedPolicyText)
+
+		return nil
+	},
+}
+
+// compilePolicyYAMLFile - reads policy written in YAML and compiles it to policy json
+func compilePolicyYAMLFile(policyPath string) (string, error) {
+	policyBytes, err := util.UseSourceUrl(policyPath)
+	if err != nil {
+		return "", err
+	}
+	policy, err := models.CompilePolicyYAML(policyBytes)
+	if err != nil {
+		return "", err
+	}
+	return models.MarshalPolicyDocument(*policy)
+}
+
+func init() {
+	policyCmd.AddCommand(policyCompileCmd)
+
+	policyCompileCmd.Flags().StringVarP(&configPath, "file", "f", "", "Policy DSL or YAML file path")
+	policyCompileCmd.MarkFlagRequired("file")
+	policyCompileCmd.Flags().StringVarP(&configFileType, "input", "i", "dsl", "Policy file type dsl or yaml, detected from file extension by default")
+	policyCompileCmd.Flags().StringVarP(&policyOutputFormat, "output", "o", "json", "Output format json or yaml")
+}
@@ -0,0 +1,170 @@
+// Copyright © 2020 Developer <developer@vamp.io>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/magneticio/forklift/core"
+	"github.com/magneticio/forklift/logging"
+	"github.com/magneticio/forklift/models"
+	"github.com/magneticio/forklift/util"
+	homedir "github.com/mitchellh/go-homedir"
+	"github.com/spf13/cobra"
+)
+
+var policyTemplateName string
+var policyTemplateSteps int
+var policyTemplateStepDuration string
+var policyTemplateMetrics []string
+var putCreatedPolicy bool
+
+var policyCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a policy from a template",
+	Long: AddAppName(`Create a policy from a built-in or user defined template
+    Built-in templates are canary, blue-green, dark-launch and validation.
+    User defined templates are loaded from ~/.$AppName/templates/<template>.json or .yaml
+    and rendered as Go templates with .Steps, .StepDuration and .Metrics parameters.
+    Usage:
+    $AppName policy create --template canary --steps 5 --metric Health>=1
+    $AppName policy create [policy_id] --template canary --put [--name <policy_name>]`),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		parameters := models.PolicyTemplateParameters{
+			Steps:        policyTemplateSteps,
+			StepDuration: policyTemplateStepDuration,
+			Metrics:      make([]models.PolicyTemplateMetric, 0, len(policyTemplateMetrics)),
+		}
+		for _, metricText := range policyTemplateMetrics {
+			metric, err := models.ParsePolicyTemplateMetric(metricText)
+			if err != nil {
+				return err
+			}
+			parameters.Metrics = append(parameters.Metrics, *metric)
+		}
+
+		logging.Info("Creating policy from template '%s'\n", policyTemplateName)
+
+		policyText, err := renderUserPolicyTemplate(policyTemplateName, parameters)
+		if err != nil {
+			return err
+		}
+		if policyText == "" {
+			policy, err := models.GeneratePolicy(policyTemplateName, parameters)
+			if err != nil {
+				return err
+			}
+			policyText, err = models.MarshalPolicyDocument(*policy)
+			if err != nil {
+				return err
+			}
+		}
+
+		if issues := models.LintPolicy(policyText); len(issues) > 0 {
+			for _, issue := range issues {
+				fmt.Fprintln(os.Stderr, issue)
+			}
+			return fmt.Errorf("Policy created from template '%s' has %d problem(s)", policyTemplateName, len(issues))
+		}
+
+		if !putCreatedPolicy {
+			prettyPolicyText, err := util.Convert("json", "json", policyText)
+			if err != nil {
+				return err
+			}
+			fmt.Println(prettyPolicyText)
+			return nil
+		}
+
+		core, err := core.NewCore(Config)
+		if err != nil {
+			return err
+		}
+
+		var policyID uint64
+		if policyName != "" && len(args) < 1 {
+			policyID, err = core.GetOrAllocatePolicyID(policyName)
+		} else {
+			policyID, err = getPolicyID(core, args, "")
+		}
+		if err != nil {
+			return err
+		}
+
+		err = core.PutPolicy(policyID, policyName, policyText)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Policy '%d' has been put\n", policyID)
+
+		return nil
+	},
+}
+
+// renderUserPolicyTemplate - renders user defined template as json
+// returns empty text if there is no user defined template with the name
+func renderUserPolicyTemplate(templateName string, parameters models.PolicyTemplateParameters) (string, error) {
+	home, err := homedir.Dir()
+	if err != nil {
+		return "", err
+	}
+	templatesPath := filepath.FromSlash(home + AddAppName("/.$AppName/templates"))
+	for _, extension := range []string{".json", ".yaml", ".yml"} {
+		templatePath := filepath.Join(templatesPath, templateName+extension)
+		templateBytes, err := ioutil.ReadFile(templatePath)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return "", fmt.Errorf("cannot read policy template '%s': %v", templatePath, err)
+		}
+
+		policyText, err := models.RenderPolicyTemplate(string(templateBytes), parameters)
+		if err != nil {
+			return "", err
+		}
+
+		inputFormat := "json"
+		if extension != ".json" {
+			inputFormat = "yaml"
+		}
+		return util.Convert(inputFormat, "json", policyText)
+	}
+	return "", nil
+}
+
+func init() {
+	policyCmd.AddCommand(policyCreateCmd)
+
+	policyCreateCmd.Flags().StringVarP(&policyTemplateName, "template", "t", "", "Template name: canary, blue-green, dark-launch, validation or a user defined template")
+	policyCreateCmd.MarkFlagRequired("template")
+	policyCreateCmd.Flags().IntVar(&policyTemplateSteps, "steps", 3, "Number of steps for canary and dark-launch templates")
+	policyCreateCmd.Flags().StringVar(&policyTemplateStepDuration, "step-duration", "5m0s", "Max duration of each step")
+	policyCreateCmd.Flags().StringArrayVar(&policyTemplateMetrics, "metric", []string{"Health>=1"}, "Metric condition like Health>=1, can be repeated")
+	policyCreateCmd.Flags().BoolVar(&putCreatedPolicy, "put", false, "Put created policy to key value store instead of printing it")
+	policyCreateCmd.Flags().StringVar(&policyName, "name", "", "Unique name of the policy")
+}
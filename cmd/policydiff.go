@@ -0,0 +1,104 @@
+// Copyright © 2020 Developer <developer@vamp.io>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/magneticio/forklift/core"
+	"github.com/magneticio/forklift/logging"
+	"github.com/magneticio/forklift/models"
+	"github.com/spf13/cobra"
+	yaml "gopkg.in/yaml.v3"
+)
+
+var policyDiffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Show semantic differences between two policies",
+	Long: AddAppName(`Show semantic differences between two policies
+    Each policy is either a policy id or a policy file path.
+    Policy files are converted the same way as stored policies before they are compared.
+    Steps are compared by position, metrics and conditions by metric name.
+    Usage:
+    $AppName policy diff <policy_id_or_file> <policy_id_or_file>`),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 2 {
+			return fmt.Errorf("Two policies expected - policy ids or policy file paths")
+		}
+
+		logging.Info("Comparing policy '%s' with policy '%s'\n", args[0], args[1])
+
+		var forkliftCore *core.Core
+		policies := make([]*models.PolicyDocument, len(args))
+		for i, arg := range args {
+			var policyText string
+			if policyID, err := strconv.ParseUint(arg, 10, 64); err == nil {
+				if forkliftCore == nil {
+					forkliftCore, err = core.NewCore(Config)
+					if err != nil {
+						return err
+					}
+				}
+				policyText, err = forkliftCore.GetPolicyString(policyID)
+				if err != nil {
+					return err
+				}
+			} else {
+				policyText, err = readPolicyFile(arg, "json", false)
+				if err != nil {
+					return err
+				}
+				policyText, err = core.NormalizePolicyString(policyText)
+				if err != nil {
+					return fmt.Errorf("cannot read policy file '%s': %v", arg, err)
+				}
+			}
+
+			policy, err := models.ParsePolicyDocument(policyText)
+			if err != nil {
+				return err
+			}
+			policies[i] = policy
+		}
+
+		changes := models.DiffPolicies(*policies[0], *policies[1])
+		if len(changes) == 0 {
+			fmt.Printf("Policies are equivalent\n")
+			return nil
+		}
+
+		output, err := yaml.Marshal(changes)
+		if err != nil {
+			return err
+		}
+
+		fmt.Print(string(output))
+
+		return nil
+	},
+}
+
+func init() {
+	policyCmd.AddCommand(policyDiffCmd)
+}
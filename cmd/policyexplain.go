@@ -0,0 +1,76 @@
+// Copyright © 2020 Developer <developer@vamp.io>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/magneticio/forklift/core"
+	"github.com/magneticio/forklift/logging"
+	"github.com/magneticio/forklift/models"
+	"github.com/spf13/cobra"
+)
+
+var policyExplainCmd = &cobra.Command{
+	Use:   "explain",
+	Short: "Explain a policy in human readable form",
+	Long: AddAppName(`Explain a policy in human readable form
+    Every step is shown with its traffic weights, target condition, duration and conditions
+    followed by a summary of actions executed on success and on failure.
+    Usage:
+    $AppName policy explain <policy_id>
+    $AppName policy explain --name <policy_name>`),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		core, err := core.NewCore(Config)
+		if err != nil {
+			return err
+		}
+
+		policyID, err := getPolicyID(core, args, policyName)
+		if err != nil {
+			return err
+		}
+
+		logging.Info("Explaining policy '%d'\n", policyID)
+
+		policyText, err := core.GetPolicyString(policyID)
+		if err != nil {
+			return err
+		}
+
+		policy, err := models.ParsePolicyDocument(policyText)
+		if err != nil {
+			return err
+		}
+
+		fmt.Print(models.ExplainPolicy(*policy))
+
+		return nil
+	},
+}
+
+func init() {
+	policyCmd.AddCommand(policyExplainCmd)
+
+	policyExplainCmd.Flags().StringVar(&policyName, "name", "", "Policy name")
+}
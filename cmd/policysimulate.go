@@ -0,0 +1,116 @@
+// Copyright © 2020 Developer <developer@vamp.io>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/magneticio/forklift/core"
+	"github.com/magneticio/forklift/logging"
+	"github.com/magneticio/forklift/models"
+	"github.com/magneticio/forklift/util"
+	"github.com/spf13/cobra"
+)
+
+var metricSeriesPath string
+
+var policySimulateCmd = &cobra.Command{
+	Use:   "simulate",
+	Short: "Simulate a release policy over recorded metric values",
+	Long: AddAppName(`Simulate a release policy over recorded metric values and print the release timeline
+    Metric series is a CSV file with a header row "time,<metric>,<metric>..."
+    where time is an offset from the release start like 90s or 1m30s.
+    Policy is read from the key value store or from a local file.
+    Usage:
+    $AppName policy simulate <policy_id> --metrics <series_csv_path>
+    $AppName policy simulate --name <policy_name> --metrics <series_csv_path>
+    $AppName policy simulate --file <policy_file_path> --metrics <series_csv_path>`),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var policyText string
+		if configPath != "" {
+			if len(args) > 0 || policyName != "" {
+				return fmt.Errorf("Either policy id, policy name or policy file can be provided")
+			}
+			logging.Info("Simulating policy '%s'\n", configPath)
+
+			var err error
+			policyText, err = readPolicyFile(configPath, configFileType, cmd.Flags().Changed("input"))
+			if err != nil {
+				return err
+			}
+		} else {
+			core, err := core.NewCore(Config)
+			if err != nil {
+				return err
+			}
+
+			policyID, err := getPolicyID(core, args, policyName)
+			if err != nil {
+				return err
+			}
+			logging.Info("Simulating policy '%d'\n", policyID)
+
+			policyText, err = core.GetPolicyString(policyID)
+			if err != nil {
+				return err
+			}
+		}
+
+		policy, err := models.ParsePolicyDocument(policyText)
+		if err != nil {
+			return err
+		}
+
+		seriesBytes, err := util.UseSourceUrl(metricSeriesPath)
+		if err != nil {
+			return err
+		}
+
+		series, err := models.ParseMetricSeries(string(seriesBytes))
+		if err != nil {
+			return err
+		}
+
+		simulation, err := models.SimulatePolicy(*policy, *series)
+		if err != nil {
+			return err
+		}
+
+		for _, event := range simulation.Events {
+			fmt.Println(event)
+		}
+		fmt.Printf("Release %s\n", simulation.Outcome)
+
+		return nil
+	},
+}
+
+func init() {
+	policyCmd.AddCommand(policySimulateCmd)
+
+	policySimulateCmd.Flags().StringVar(&policyName, "name", "", "Policy name")
+	policySimulateCmd.Flags().StringVarP(&configPath, "file", "f", "", "Policy configuration file path")
+	policySimulateCmd.Flags().StringVarP(&configFileType, "input", "i", "json", "Policy configuration file type yaml or json, detected from file extension by default")
+	policySimulateCmd.Flags().StringVar(&metricSeriesPath, "metrics", "", "Metric series CSV file path")
+	policySimulateCmd.MarkFlagRequired("metrics")
+}
@@ -0,0 +1,93 @@
+// Copyright © 2020 Developer <developer@vamp.io>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/magneticio/forklift/core"
+	"github.com/magneticio/forklift/logging"
+	"github.com/magneticio/forklift/models"
+	"github.com/spf13/cobra"
+)
+
+var hookEvent string
+var hookTarget string
+var hookTimeout time.Duration
+
+var policyTestHooksCmd = &cobra.Command{
+	Use:   "test-hooks",
+	Short: "Send policy hook requests with a sample payload",
+	Long: AddAppName(`Send onSuccess or onFailure hook requests of a policy with a sample payload
+    and report status, latency and response body of each request.
+    Secret references in headers are resolved. With --target requests are sent
+    to the target instead of the hosts defined in the policy, keeping paths.
+    Usage:
+    $AppName policy test-hooks <policy_id> --event success|failure [--target http://localhost:8080]
+    $AppName policy test-hooks --name <policy_name> --event success|failure`),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		core, err := core.NewCore(Config)
+		if err != nil {
+			return err
+		}
+
+		policyID, err := getPolicyID(core, args, policyName)
+		if err != nil {
+			return err
+		}
+
+		logging.Info("Testing %s hooks of policy '%d'\n", hookEvent, policyID)
+
+		results, err := core.TestPolicyHooks(policyID, hookEvent, hookTarget, hookTimeout)
+		if err != nil {
+			return err
+		}
+
+		failed := 0
+		for _, result := range results {
+			fmt.Println(result)
+			if result.Body != "" {
+				fmt.Println(result.Body)
+			}
+			if result.Error != "" {
+				failed++
+			}
+		}
+
+		if failed > 0 {
+			return fmt.Errorf("%d of %d hook(s) failed", failed, len(results))
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	policyCmd.AddCommand(policyTestHooksCmd)
+
+	policyTestHooksCmd.Flags().StringVar(&policyName, "name", "", "Policy name")
+	policyTestHooksCmd.Flags().StringVar(&hookEvent, "event", models.HookEventSuccess, "Event whose hooks are sent, success or failure")
+	policyTestHooksCmd.Flags().StringVar(&hookTarget, "target", "", "Send requests to this url instead of the hosts defined in the policy")
+	policyTestHooksCmd.Flags().DurationVar(&hookTimeout, "timeout", 10*time.Second, "Timeout of each request")
+}
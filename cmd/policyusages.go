@@ -0,0 +1,104 @@
+// Copyright © 2020 Developer <developer@vamp.io>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/magneticio/forklift/core"
+	"github.com/magneticio/forklift/logging"
+	"github.com/spf13/cobra"
+	yaml "gopkg.in/yaml.v3"
+)
+
+var unusedPolicies bool
+
+var policyUsagesCmd = &cobra.Command{
+	Use:   "usages",
+	Short: "Show where policies are used",
+	Long: AddAppName(`Show service configs referencing a policy in all clusters and applications
+    or list policies which are not referenced by any service config
+    Usage:
+    $AppName policy usages <policy_id>
+    $AppName policy usages --unused`),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if unusedPolicies {
+			logging.Info("Listing unused policies\n")
+			core, err := core.NewCore(Config)
+			if err != nil {
+				return err
+			}
+
+			policies, err := core.FindUnusedPolicies()
+			if err != nil {
+				return err
+			}
+
+			output, err := yaml.Marshal(policies)
+			if err != nil {
+				return err
+			}
+
+			fmt.Print(string(output))
+
+			return nil
+		}
+
+		if len(args) < 1 {
+			return fmt.Errorf("Not enough arguments - policy id needed")
+		}
+		policyIDString := args[0]
+
+		policyID, err := strconv.ParseUint(policyIDString, 10, 64)
+		if err != nil {
+			return fmt.Errorf("Policy id '%s' must be a natural number", policyIDString)
+		}
+
+		logging.Info("Showing usages of policy '%d'\n", policyID)
+		core, err := core.NewCore(Config)
+		if err != nil {
+			return err
+		}
+
+		references, err := core.FindPolicyReferences(policyID)
+		if err != nil {
+			return err
+		}
+
+		output, err := yaml.Marshal(references)
+		if err != nil {
+			return err
+		}
+
+		fmt.Print(string(output))
+
+		return nil
+	},
+}
+
+func init() {
+	policyCmd.AddCommand(policyUsagesCmd)
+
+	policyUsagesCmd.Flags().BoolVar(&unusedPolicies, "unused", false, "List policies which are not referenced by any service config")
+}
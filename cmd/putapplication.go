@@ -22,21 +22,26 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 	"strconv"
 
 	"github.com/magneticio/forklift/core"
 	"github.com/magneticio/forklift/logging"
+	"github.com/magneticio/forklift/models"
 	"github.com/spf13/cobra"
 )
 
-var namespace string
+var namespaces []string
+var forceNamespace bool
 
 var putApplicationCmd = &cobra.Command{
 	Use:   "application",
 	Short: "Put an application",
 	Long: AddAppName(`Put an application
     Usage:
-    $AppName put application <application_id> --namespace <namespace>`),
+    $AppName put application <application_id> --namespace <namespace> [--namespace <namespace>...] [--force]
+    Namespaces of the application which are not listed are removed.
+    Namespace used by another application is reassigned only with --force flag.`),
 	SilenceUsage:  true,
 	SilenceErrors: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -55,20 +60,37 @@ var putApplicationCmd = &cobra.Command{
 			return err
 		}
 
-		err = core.PutApplication(applicationID, namespace)
+		displacedApplications, err := core.PutApplication(applicationID, namespaces, forceNamespace)
 		if err != nil {
 			return err
 		}
 
 		fmt.Printf("Application '%d' has been put\n", applicationID)
 
+		for _, displacedApplication := range displacedApplications {
+			printDisplacedApplicationWarning(displacedApplication)
+		}
+
 		return nil
 	},
 }
 
+func printDisplacedApplicationWarning(displacedApplication models.DisplacedApplication) {
+	for _, namespace := range displacedApplication.Namespaces {
+		fmt.Fprintf(os.Stderr, "Warning: namespace '%s' has been taken from application '%d'\n", namespace, displacedApplication.ID)
+	}
+	if len(displacedApplication.ServiceConfigIDs) > 0 {
+		fmt.Fprintf(os.Stderr, "Warning: service configs %v are left orphaned under '%s'\n", displacedApplication.ServiceConfigIDs, displacedApplication.Path)
+	}
+	if len(displacedApplication.ReleasePlanServiceIDs) > 0 {
+		fmt.Fprintf(os.Stderr, "Warning: release plans of services %v are left orphaned under '%s'\n", displacedApplication.ReleasePlanServiceIDs, displacedApplication.Path)
+	}
+}
+
 func init() {
 	putCmd.AddCommand(putApplicationCmd)
 
-	putApplicationCmd.Flags().StringVar(&namespace, "namespace", "", "Kubernetes namespace")
+	putApplicationCmd.Flags().StringArrayVar(&namespaces, "namespace", nil, "Kubernetes namespace, can be repeated")
 	putApplicationCmd.MarkFlagRequired("namespace")
+	putApplicationCmd.Flags().BoolVar(&forceNamespace, "force", false, "Reassign namespace used by another application")
 }
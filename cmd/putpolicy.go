@@ -22,11 +22,9 @@ package cmd
 
 import (
 	"fmt"
-	"strconv"
 
 	"github.com/magneticio/forklift/core"
 	"github.com/magneticio/forklift/logging"
-	"github.com/magneticio/forklift/util"
 	"github.com/spf13/cobra"
 )
 
@@ -35,38 +33,36 @@ var putPolicyCmd = &cobra.Command{
 	Short: "Put a policy",
 	Long: AddAppName(`Put a policy
     Usage:
-    $AppName put policy <policy_id> --file <policy_file_path>`),
+    $AppName put policy <policy_id> --file <policy_file_path> [--name <policy_name>]
+    $AppName put policy --name <policy_name> --file <policy_file_path>
+    $AppName put policy <policy_id> --file <policy_dsl_file_path> --input dsl
+    When only the name is provided, the policy with this name is updated
+    or a new policy id is allocated if there is no such policy yet.`),
 	SilenceUsage:  true,
 	SilenceErrors: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		if len(args) < 1 {
-			return fmt.Errorf("Not enough arguments - policy id needed")
-		}
-		policyIDString := args[0]
-
-		policyID, err := strconv.ParseUint(policyIDString, 10, 64)
-		if err != nil {
-			return fmt.Errorf("Policy id '%s' must be a natural number", policyIDString)
-		}
-		logging.Info("Puting policy '%d'\n", policyID)
 		core, err := core.NewCore(Config)
 		if err != nil {
 			return err
 		}
 
-		policyBytes, err := util.UseSourceUrl(configPath)
+		var policyID uint64
+		if policyName != "" && len(args) < 1 {
+			policyID, err = core.GetOrAllocatePolicyID(policyName)
+		} else {
+			policyID, err = getPolicyID(core, args, "")
+		}
 		if err != nil {
 			return err
 		}
+		logging.Info("Puting policy '%d'\n", policyID)
 
-		policyJSON, err := util.Convert(configFileType, "json", policyBytes)
+		policyText, err := readPolicyFile(configPath, configFileType, true)
 		if err != nil {
 			return err
 		}
 
-		policyText := string(policyJSON)
-
-		err = core.PutPolicy(policyID, policyText)
+		err = core.PutPolicy(policyID, policyName, policyText)
 		if err != nil {
 			return err
 		}
@@ -82,5 +78,6 @@ func init() {
 
 	putPolicyCmd.Flags().StringVarP(&configPath, "file", "f", "", "Policy configuration file path")
 	putPolicyCmd.MarkFlagRequired("file")
-	putPolicyCmd.Flags().StringVarP(&configFileType, "input", "i", "json", "Policy configuration file type yaml or json")
+	putPolicyCmd.Flags().StringVar(&policyName, "name", "", "Unique name of the policy")
+	putPolicyCmd.Flags().StringVarP(&configFileType, "input", "i", "json", "Policy configuration file type yaml, json or dsl")
 }
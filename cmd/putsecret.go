@@ -0,0 +1,82 @@
+// Copyright © 2020 Developer <developer@vamp.io>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/magneticio/forklift/core"
+	"github.com/magneticio/forklift/logging"
+	"github.com/magneticio/forklift/util"
+	"github.com/spf13/cobra"
+)
+
+var putSecretCmd = &cobra.Command{
+	Use:   "secret",
+	Short: "Put a secret",
+	Long: AddAppName(`Put a project secret which can be referenced in policy hook headers
+    as ${secret:project/<secret_name>}
+    Without --file the value is read from the terminal so that it does not end up in shell history.
+    Usage:
+    $AppName put secret <secret_name> --file <secret_file_path>
+    $AppName put secret <secret_name>`),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 1 {
+			return fmt.Errorf("Not enough arguments - secret name needed")
+		}
+		secretName := args[0]
+
+		var value string
+		var err error
+		if configPath != "" {
+			value, err = util.UseSourceUrl(configPath)
+		} else {
+			value, err = util.GetParameterFromTerminalAsSecret("Enter secret value:", "Enter secret value again:", "Secret values do not match")
+		}
+		if err != nil {
+			return err
+		}
+
+		logging.Info("Putting secret '%s'\n", secretName)
+
+		core, err := core.NewCore(Config)
+		if err != nil {
+			return err
+		}
+
+		err = core.PutSecret(secretName, value)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Secret '%s' has been put\n", secretName)
+
+		return nil
+	},
+}
+
+func init() {
+	putCmd.AddCommand(putSecretCmd)
+
+	putSecretCmd.Flags().StringVarP(&configPath, "file", "f", "", "Secret value file path")
+}
@@ -29,12 +29,15 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var allowSharedIngress bool
+
 var putServiceCmd = &cobra.Command{
 	Use:   "service",
 	Short: "Put a service",
 	Long: AddAppName(`Put a service
     Usage:
-    $AppName put service --cluster <cluster_id> --file <service_config_file_path>`),
+    $AppName put service --cluster <cluster_id> --file <service_config_file_path>
+    Ingress domain and path pairs already used by other services of the cluster are rejected unless --allow-shared is given.`),
 	SilenceUsage:  true,
 	SilenceErrors: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -56,7 +59,7 @@ var putServiceCmd = &cobra.Command{
 
 		serviceConfigText := string(serviceConfigJSON)
 
-		err = core.PutServiceConfig(serviceConfigText)
+		err = core.PutServiceConfig(serviceConfigText, allowSharedIngress)
 		if err != nil {
 			return err
 		}
@@ -73,4 +76,5 @@ func init() {
 	putServiceCmd.Flags().StringVarP(&configPath, "file", "f", "", "Service configuration file path")
 	putServiceCmd.MarkFlagRequired("file")
 	putServiceCmd.Flags().StringVarP(&configFileType, "input", "i", "json", "Service configuration file type yaml or json")
+	putServiceCmd.Flags().BoolVar(&allowSharedIngress, "allow-shared", false, "Allow ingress domain and path already used by another service of the cluster")
 }
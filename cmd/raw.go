@@ -0,0 +1,56 @@
+// Copyright © 2020 Developer <developer@vamp.io>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var rawWriteConfirmed bool
+
+var rawCmd = &cobra.Command{
+	Use:   "raw",
+	Short: "Raw key value store operations",
+	Long: AddAppName(`Raw key value store operations on keys relative to the project path
+    Values are neither validated nor checked for references, writes require --i-know-what-im-doing flag.
+    Example:
+    $AppName raw get clusters/<cluster_id>/release-agent-config`),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return errors.New("An operation expected")
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(rawCmd)
+}
+
+// checkRawWriteConfirmed - checks that raw write has been confirmed with --i-know-what-im-doing flag
+func checkRawWriteConfirmed() error {
+	if !rawWriteConfirmed {
+		return fmt.Errorf("Raw writes bypass all validation, use --i-know-what-im-doing flag to proceed")
+	}
+	return nil
+}
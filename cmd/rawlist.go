@@ -0,0 +1,66 @@
+// Copyright © 2020 Developer <developer@vamp.io>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/magneticio/forklift/core"
+	"github.com/magneticio/forklift/logging"
+	"github.com/spf13/cobra"
+)
+
+var rawListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List keys of a directory",
+	Long: AddAppName(`List keys of a directory relative to the project path, directories end with a slash
+    Usage:
+    $AppName raw list [<relative_directory>]`),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		directory := ""
+		if len(args) > 0 {
+			directory = args[0]
+		}
+
+		logging.Info("Listing keys of '%s'\n", directory)
+		core, err := core.NewCore(Config)
+		if err != nil {
+			return err
+		}
+
+		entries, err := core.ListRawKeys(directory)
+		if err != nil {
+			return err
+		}
+
+		for _, entry := range entries {
+			fmt.Println(entry)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	rawCmd.AddCommand(rawListCmd)
+}
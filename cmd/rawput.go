@@ -0,0 +1,87 @@
+// Copyright © 2020 Developer <developer@vamp.io>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/magneticio/forklift/core"
+	"github.com/magneticio/forklift/logging"
+	"github.com/magneticio/forklift/util"
+	"github.com/spf13/cobra"
+)
+
+var rawValue string
+
+var rawPutCmd = &cobra.Command{
+	Use:   "put",
+	Short: "Put raw value of a key",
+	Long: AddAppName(`Put raw value of a key relative to the project path without any validation
+    Usage:
+    $AppName raw put <relative_key> --file <value_file_path> --i-know-what-im-doing
+    $AppName raw put <relative_key> --value <value> --i-know-what-im-doing`),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 1 {
+			return fmt.Errorf("Not enough arguments - key needed")
+		}
+		key := args[0]
+
+		if err := checkRawWriteConfirmed(); err != nil {
+			return err
+		}
+		if (configPath == "") == (rawValue == "") {
+			return fmt.Errorf("Either value file or value must be provided")
+		}
+
+		value := rawValue
+		if configPath != "" {
+			var err error
+			value, err = util.UseSourceUrl(configPath)
+			if err != nil {
+				return err
+			}
+		}
+
+		logging.Info("Putting key '%s'\n", key)
+		core, err := core.NewCore(Config)
+		if err != nil {
+			return err
+		}
+
+		if err := core.PutRawKey(key, value); err != nil {
+			return err
+		}
+
+		fmt.Printf("Key '%s' has been put\n", key)
+
+		return nil
+	},
+}
+
+func init() {
+	rawCmd.AddCommand(rawPutCmd)
+
+	rawPutCmd.Flags().StringVarP(&configPath, "file", "f", "", "Value file path")
+	rawPutCmd.Flags().StringVar(&rawValue, "value", "", "Value")
+	rawPutCmd.Flags().BoolVar(&rawWriteConfirmed, "i-know-what-im-doing", false, "Confirm writing a key without validation")
+}
@@ -0,0 +1,130 @@
+// Copyright © 2020 Developer <developer@vamp.io>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/magneticio/forklift/core"
+	"github.com/magneticio/forklift/logging"
+	"github.com/magneticio/forklift/util"
+	"github.com/spf13/cobra"
+)
+
+var releasePlanServiceName string
+var releasePlanGroups []string
+var putGeneratedReleasePlan bool
+
+var generateReleasePlanCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "Generate a release plan",
+	Long: AddAppName(`Generate a not started release plan for a new service version
+    The policy is selected from the service config based on the version bump
+    against the latest existing release plan. Release groups are copied from
+    the latest release plan unless provided with --group flags, each flag
+    being a comma separated list of environments.
+    Usage:
+    $AppName releaseplan generate <service_version> --cluster <cluster_id> --application <application_id> --service <service_id> [--group <environment>[,<environment>]] [--put]`),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 1 {
+			return errors.New("Not enough arguments, service version needed")
+		}
+		serviceVersion := args[0]
+
+		logging.Info("Generating release plan for service version: '%s'\n", serviceVersion)
+
+		core, err := core.NewCore(Config)
+		if err != nil {
+			return err
+		}
+
+		releaseGroups := make([][]string, 0, len(releasePlanGroups))
+		for _, releasePlanGroup := range releasePlanGroups {
+			environments := make([]string, 0)
+			for _, environment := range strings.Split(releasePlanGroup, ",") {
+				if environment = strings.TrimSpace(environment); environment != "" {
+					environments = append(environments, environment)
+				}
+			}
+			if len(environments) == 0 {
+				return fmt.Errorf("Release group must contain at least one environment")
+			}
+			releaseGroups = append(releaseGroups, environments)
+		}
+
+		generatedReleasePlan, err := core.GenerateReleasePlan(applicationID, serviceID, serviceVersion, releasePlanServiceName, releaseGroups)
+		if err != nil {
+			return err
+		}
+
+		releasePlanJSON, err := json.Marshal(generatedReleasePlan.ReleasePlan)
+		if err != nil {
+			return err
+		}
+
+		policyInfo := fmt.Sprintf(
+			"Release plan for %s version bump uses %s policy '%d'\n",
+			generatedReleasePlan.Bump,
+			generatedReleasePlan.Policy.Slot,
+			generatedReleasePlan.Policy.PolicyID,
+		)
+
+		if putGeneratedReleasePlan {
+			err = core.PutReleasePlan(applicationID, serviceID, serviceVersion, string(releasePlanJSON))
+			if err != nil {
+				return err
+			}
+			fmt.Print(policyInfo)
+			fmt.Printf("Release plan for service version '%s' has been put\n", serviceVersion)
+			return nil
+		}
+
+		prettyReleasePlanText, err := util.Convert("json", "json", string(releasePlanJSON))
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprint(os.Stderr, policyInfo)
+		fmt.Println(prettyReleasePlanText)
+
+		return nil
+	},
+}
+
+func init() {
+	releasePlanCmd.AddCommand(generateReleasePlanCmd)
+
+	generateReleasePlanCmd.Flags().Uint64VarP(&applicationID, "application", "a", 0, "ID of the application")
+	generateReleasePlanCmd.MarkFlagRequired("application")
+
+	generateReleasePlanCmd.Flags().Uint64VarP(&serviceID, "service", "s", 0, "ID of the service")
+	generateReleasePlanCmd.MarkFlagRequired("service")
+
+	generateReleasePlanCmd.Flags().StringVar(&releasePlanServiceName, "name", "", "Service name, defaults to the name from the latest release plan or the 'app' label")
+	generateReleasePlanCmd.Flags().StringArrayVar(&releasePlanGroups, "group", nil, "Comma separated list of environments in a release group, can be repeated")
+	generateReleasePlanCmd.Flags().BoolVar(&putGeneratedReleasePlan, "put", false, "Put generated release plan to key value store instead of printing it")
+}
@@ -0,0 +1,99 @@
+// Copyright © 2020 Developer <developer@vamp.io>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/magneticio/forklift/core"
+	"github.com/magneticio/forklift/logging"
+	"github.com/magneticio/forklift/util"
+	"github.com/spf13/cobra"
+)
+
+var fromVersion string
+var toVersion string
+
+var resolvePolicyCmd = &cobra.Command{
+	Use:   "policy",
+	Short: "Resolve policy for a service version bump",
+	Long: AddAppName(`Resolve policy which will be used to release a new service version
+    If --from is not provided, the version of the latest release plan is used.
+    Usage:
+    $AppName resolve policy --cluster <cluster_id> --application <application_id> --service <service_id> --from <version> --to <version>
+    Values of hook headers are masked unless --reveal is set.`),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logging.Info("Resolving policy for service '%d' version '%s'\n", serviceID, toVersion)
+		core, err := core.NewCore(Config)
+		if err != nil {
+			return err
+		}
+
+		resolution, err := core.ResolvePolicy(applicationID, serviceID, fromVersion, toVersion)
+		if err != nil {
+			return err
+		}
+
+		policyString, err := maskPolicyText(resolution.PolicyText)
+		if err != nil {
+			return err
+		}
+
+		prettyPolicyString, err := util.Convert("json", "json", policyString)
+		if err != nil {
+			return err
+		}
+
+		selection := "default fallback"
+		if resolution.Policy.Explicit {
+			selection = "explicit"
+		}
+
+		if resolution.FromVersion != "" {
+			fmt.Printf("Version bump: %s (%s -> %s)\n", resolution.Bump, resolution.FromVersion, resolution.ToVersion)
+		} else {
+			fmt.Printf("Version bump: %s (-> %s)\n", resolution.Bump, resolution.ToVersion)
+		}
+		fmt.Printf("Policy: %d\n", resolution.Policy.PolicyID)
+		fmt.Printf("Selection: %s\n", selection)
+		fmt.Printf("Reason: %s\n", resolution.Policy.Reason)
+		fmt.Print(prettyPolicyString)
+
+		return nil
+	},
+}
+
+func init() {
+	resolveCmd.AddCommand(resolvePolicyCmd)
+
+	resolvePolicyCmd.Flags().Uint64VarP(&applicationID, "application", "a", 0, "ID of the application")
+	resolvePolicyCmd.MarkFlagRequired("application")
+
+	resolvePolicyCmd.Flags().Uint64VarP(&serviceID, "service", "s", 0, "ID of the service")
+	resolvePolicyCmd.MarkFlagRequired("service")
+
+	resolvePolicyCmd.Flags().StringVar(&fromVersion, "from", "", "Currently released service version")
+	resolvePolicyCmd.Flags().StringVar(&toVersion, "to", "", "Service version to be released")
+	resolvePolicyCmd.MarkFlagRequired("to")
+	resolvePolicyCmd.Flags().BoolVar(&revealPolicyHeaders, "reveal", false, "Show values of hook headers")
+}
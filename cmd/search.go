@@ -0,0 +1,185 @@
+// Copyright © 2020 Developer <developer@vamp.io>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/magneticio/forklift/core"
+	"github.com/magneticio/forklift/logging"
+	"github.com/magneticio/forklift/models"
+	homedir "github.com/mitchellh/go-homedir"
+	"github.com/spf13/cobra"
+	yaml "gopkg.in/yaml.v3"
+)
+
+var useSearchCache bool
+var refreshSearchCache bool
+var searchCacheTTL time.Duration
+
+var searchCmd = &cobra.Command{
+	Use:   "search",
+	Short: "Search resources of the project",
+	Long: AddAppName(`Search clusters, applications, services and policies of the project
+    Query consists of terms which all have to match, terms have form <field>:<value> or just <value>.
+    Fields are domain, path, tls-secret, label, namespace, nats-channel and metric.
+    Field values match whole values and can contain * wildcards, values without a field match any part of any field.
+    Double quotes keep a phrase with spaces in one term, like metric:"available replicas".
+    With --cache the search index is kept locally and rebuilt when it is older than --cache-ttl.
+    Usage:
+    $AppName search <query> [--cache [--cache-ttl <duration>] [--refresh]]
+    Example:
+    $AppName search domain:shop.example.com
+    $AppName search label:app=checkout namespace:shop*
+    $AppName search 'metric:"available replicas"'`),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 1 {
+			return fmt.Errorf("Not enough arguments - search query needed")
+		}
+		// arguments quoted in the shell arrive with spaces, they are quoted again to stay one term
+		terms := make([]string, len(args))
+		for i, arg := range args {
+			terms[i] = arg
+			if strings.ContainsAny(arg, " \t") && !strings.Contains(arg, `"`) {
+				terms[i] = `"` + arg + `"`
+			}
+		}
+		query, err := models.ParseSearchQuery(strings.Join(terms, " "))
+		if err != nil {
+			return err
+		}
+
+		logging.Info("Searching project\n")
+		index, err := getSearchIndex()
+		if err != nil {
+			return err
+		}
+
+		results := index.Search(query)
+		if len(results) == 0 {
+			fmt.Printf("No matching resources found\n")
+			return nil
+		}
+
+		output, err := yaml.Marshal(results)
+		if err != nil {
+			return err
+		}
+
+		fmt.Print(string(output))
+
+		return nil
+	},
+}
+
+// getSearchIndex - gets search index from local cache if enabled and fresh, or builds it from the key value store
+func getSearchIndex() (*models.SearchIndex, error) {
+	var cachePath string
+	if useSearchCache {
+		var err error
+		cachePath, err = getSearchIndexCachePath()
+		if err != nil {
+			return nil, err
+		}
+		if !refreshSearchCache {
+			if index, ok := readSearchIndexCache(cachePath, searchCacheTTL); ok {
+				logging.Info("Using search index built at %s\n", index.BuiltAt.Format(time.RFC3339))
+				return index, nil
+			}
+		}
+	}
+
+	core, err := core.NewCore(Config)
+	if err != nil {
+		return nil, err
+	}
+	index, err := core.BuildSearchIndex()
+	if err != nil {
+		return nil, err
+	}
+
+	if useSearchCache {
+		if err := writeSearchIndexCache(cachePath, *index); err != nil {
+			logging.Error("cannot cache search index: %v", err)
+		}
+	}
+	return index, nil
+}
+
+// getSearchIndexCachePath - gets path of the search index cache file
+// which is specific to the key value store and the project
+func getSearchIndexCachePath() (string, error) {
+	if Config.ProjectID == nil {
+		return "", fmt.Errorf("project id must be provided")
+	}
+	home, err := homedir.Dir()
+	if err != nil {
+		return "", err
+	}
+	hash := sha1.Sum([]byte(fmt.Sprintf("%s|%s|%d", Config.KeyValueStoreURL, Config.KeyValueStoreBasePath, *Config.ProjectID)))
+	cacheName := fmt.Sprintf("search-index-%s.json", hex.EncodeToString(hash[:8]))
+	return filepath.Join(filepath.FromSlash(home+AddAppName("/.$AppName/cache")), cacheName), nil
+}
+
+func readSearchIndexCache(cachePath string, ttl time.Duration) (*models.SearchIndex, bool) {
+	indexBytes, err := ioutil.ReadFile(cachePath)
+	if err != nil {
+		return nil, false
+	}
+	var index models.SearchIndex
+	if err := json.Unmarshal(indexBytes, &index); err != nil {
+		logging.Error("ignoring invalid search index cache '%s': %v", cachePath, err)
+		return nil, false
+	}
+	if time.Since(index.BuiltAt) > ttl {
+		return nil, false
+	}
+	return &index, true
+}
+
+func writeSearchIndexCache(cachePath string, index models.SearchIndex) error {
+	indexBytes, err := json.Marshal(index)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(cachePath), 0700); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(cachePath, indexBytes, 0600)
+}
+
+func init() {
+	rootCmd.AddCommand(searchCmd)
+
+	searchCmd.Flags().BoolVar(&useSearchCache, "cache", false, "Keep search index in a local cache")
+	searchCmd.Flags().BoolVar(&refreshSearchCache, "refresh", false, "Rebuild cached search index")
+	searchCmd.Flags().DurationVar(&searchCacheTTL, "cache-ttl", 10*time.Minute, "Maximum age of cached search index")
+}
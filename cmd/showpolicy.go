@@ -22,11 +22,9 @@ package cmd
 
 import (
 	"fmt"
-	"strconv"
 
 	"github.com/magneticio/forklift/core"
 	"github.com/magneticio/forklift/logging"
-	"github.com/magneticio/forklift/util"
 	"github.com/spf13/cobra"
 )
 
@@ -35,37 +33,42 @@ var showPolicyCmd = &cobra.Command{
 	Short: "Show existing policy",
 	Long: AddAppName(`Show existing policy
     Usage:
-    $AppName show policy <policy_id>`),
+    $AppName show policy <policy_id>
+    $AppName show policy --name <policy_name>
+    $AppName show policy <policy_id> --output dsl
+    Values of hook headers are masked unless --reveal is set,
+    headers referencing secrets are shown as they are.`),
 	SilenceUsage:  true,
 	SilenceErrors: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		if len(args) < 1 {
-			return fmt.Errorf("Not enough arguments - policy id needed")
+		core, err := core.NewCore(Config)
+		if err != nil {
+			return err
 		}
-		policyIDString := args[0]
 
-		policyID, err := strconv.ParseUint(policyIDString, 10, 64)
+		policyID, err := getPolicyID(core, args, policyName)
 		if err != nil {
-			return fmt.Errorf("Policy id '%s' must be a natural number", policyIDString)
+			return err
 		}
 
 		logging.Info("Showing policy '%d'\n", policyID)
-		core, err := core.NewCore(Config)
+
+		policyString, err := core.GetPolicyString(policyID)
 		if err != nil {
 			return err
 		}
 
-		policyString, err := core.GetPolicyString(policyID)
+		policyString, err = maskPolicyText(policyString)
 		if err != nil {
 			return err
 		}
 
-		prettyPolicyString, err := util.Convert("json", "json", policyString)
+		formattedPolicyString, err := formatPolicy(policyString, policyOutputFormat)
 		if err != nil {
 			return err
 		}
 
-		fmt.Print(prettyPolicyString)
+		fmt.Print(formattedPolicyString)
 
 		return nil
 	},
@@ -73,4 +76,8 @@ var showPolicyCmd = &cobra.Command{
 
 func init() {
 	showCmd.AddCommand(showPolicyCmd)
+
+	showPolicyCmd.Flags().StringVar(&policyName, "name", "", "Name of the policy")
+	showPolicyCmd.Flags().StringVarP(&policyOutputFormat, "output", "o", "json", "Output format json, yaml or dsl")
+	showPolicyCmd.Flags().BoolVar(&revealPolicyHeaders, "reveal", false, "Show values of hook headers")
 }
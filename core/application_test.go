@@ -0,0 +1,58 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/magneticio/forklift/models"
+)
+
+func TestPutApplicationListsKeysOfDisplacedApplications(t *testing.T) {
+	clusterID := uint64(1)
+	store := memoryKeyValueStore{
+		"vamp/projects/1/clusters/1/release-agent-config":                 `{"applications":{"shop":2,"cart":3}}`,
+		"vamp/projects/1/clusters/1/applications/2/service-configs/5":     `{}`,
+		"vamp/projects/1/clusters/1/applications/2/release-plans/5/1.0.0": `{}`,
+	}
+	core := &Core{kvClient: strictListKeyValueStore{store}, projectPath: "vamp/projects/1", clusterID: &clusterID}
+
+	displaced, err := core.PutApplication(4, []string{"shop", "cart"}, true)
+	if err != nil {
+		t.Fatalf("PutApplication() error = %v", err)
+	}
+	want := []models.DisplacedApplication{
+		{
+			ID:                    2,
+			Namespaces:            []string{"shop"},
+			Orphaned:              true,
+			Path:                  "vamp/projects/1/clusters/1/applications/2",
+			ServiceConfigIDs:      []uint64{5},
+			ReleasePlanServiceIDs: []uint64{5},
+		},
+		{
+			ID:         3,
+			Namespaces: []string{"cart"},
+			Orphaned:   true,
+			Path:       "vamp/projects/1/clusters/1/applications/3",
+		},
+	}
+	if !reflect.DeepEqual(displaced, want) {
+		t.Errorf("PutApplication() = %+v, want %+v", displaced, want)
+	}
+}
+
+func TestPutApplicationFailsWhenDisplacedApplicationCannotBeListed(t *testing.T) {
+	clusterID := uint64(1)
+	store := failingListKeyValueStore{
+		memoryKeyValueStore: memoryKeyValueStore{
+			"vamp/projects/1/clusters/1/release-agent-config":             `{"applications":{"shop":2}}`,
+			"vamp/projects/1/clusters/1/applications/2/service-configs/5": `{}`,
+		},
+		failingDirectory: "vamp/projects/1/clusters/1/applications/2/service-configs",
+	}
+	core := &Core{kvClient: store, projectPath: "vamp/projects/1", clusterID: &clusterID}
+
+	if displaced, err := core.PutApplication(4, []string{"shop"}, true); err == nil {
+		t.Errorf("PutApplication() = %+v, want error", displaced)
+	}
+}
@@ -5,7 +5,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"path"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/magneticio/forklift/keyvaluestoreclient"
 	"github.com/magneticio/forklift/logging"
@@ -49,19 +51,49 @@ func NewCore(conf models.ForkliftConfiguration) (*Core, error) {
 }
 
 // PutPolicy - puts policy to key value store
-func (c *Core) PutPolicy(policyID uint64, policyContent string) error {
+// if policy name is not empty it replaces the current name of the policy
+// secrets referenced in hook headers must exist
+func (c *Core) PutPolicy(policyID uint64, policyName string, policyContent string) error {
+	if policyName != "" {
+		if err := c.checkPolicyName(policyID, policyName); err != nil {
+			return err
+		}
+	}
+	if err := c.checkPolicySecrets(policyContent); err != nil {
+		return err
+	}
 	policyAPI := policies.NewPolicyAPI(c.kvClient, c.projectPath)
-	return policyAPI.Save(strconv.FormatUint(policyID, 10), policyContent)
+	if err := policyAPI.Save(strconv.FormatUint(policyID, 10), policyContent); err != nil {
+		return err
+	}
+	if policyName != "" {
+		return c.setPolicyName(policyID, policyName)
+	}
+	return nil
 }
 
 // DeletePolicy - deletes policy from key value store
-func (c *Core) DeletePolicy(policyID uint64) error {
+// policy referenced by any service config is deleted only if force is set
+func (c *Core) DeletePolicy(policyID uint64, force bool) error {
 	policyAPI := policies.NewPolicyAPI(c.kvClient, c.projectPath)
 	policyKey := strconv.FormatUint(policyID, 10)
 	_, err := policyAPI.Find(policyKey)
 	if err != nil {
 		return fmt.Errorf("cannot find policy: %v", err)
 	}
+	if !force {
+		references, err := c.FindPolicyReferences(policyID)
+		if err != nil {
+			return err
+		}
+		if len(references) > 0 {
+			return newPolicyReferencedError(policyID, references)
+		}
+	}
+	// the name is removed first so that a failure never leaves a name pointing to a deleted policy
+	if err := c.removePolicyName(policyID); err != nil {
+		return fmt.Errorf("cannot remove policy name: %v", err)
+	}
 	return policyAPI.Delete(policyKey)
 }
 
@@ -74,10 +106,12 @@ func (c *Core) ListPolicies() ([]models.PolicyView, error) {
 		return nil, fmt.Errorf("no policies found")
 	}
 
+	policyNames := c.getPolicyNamesByID()
 	policyViews := make([]models.PolicyView, len(apiPolicyViews))
 	for i, apiPolicyView := range apiPolicyViews {
 		policyViews[i] = models.PolicyView{
 			ID:   apiPolicyView.PolicyID,
+			Name: policyNames[apiPolicyView.PolicyID],
 			Type: string(apiPolicyView.PolicyType),
 		}
 	}
@@ -109,6 +143,20 @@ func (c *Core) GetPolicyString(policyID uint64) (string, error) {
 	return "", fmt.Errorf("unsupported policy type: %v", policyView.PolicyType)
 }
 
+// NormalizePolicyString - converts policy text to the form returned by GetPolicyString
+// the policy is parsed and converted in memory so that a policy file can be compared with stored policies
+func NormalizePolicyString(policyText string) (string, error) {
+	scratch := &Core{
+		kvClient:    make(memoryKeyValueStore),
+		projectPath: "policies",
+	}
+	policyAPI := policies.NewPolicyAPI(scratch.kvClient, scratch.projectPath)
+	if err := policyAPI.Save("1", policyText); err != nil {
+		return "", fmt.Errorf("cannot parse policy: %v", err)
+	}
+	return scratch.GetPolicyString(1)
+}
+
 // PutReleasePlan - puts release plan to key value store
 func (c *Core) PutReleasePlan(applicationID, serviceID uint64, serviceVersion string, releasePlanContent string) error {
 	releasePlanKey, err := c.getReleasePlanKey(applicationID, serviceID, serviceVersion)
@@ -212,10 +260,162 @@ func (c *Core) DeleteReleaseAgentConfig(clusterID uint64) error {
 	return c.kvClient.Delete(releaseAgentConfigKey)
 }
 
+// GetClusterKeyTree - gets Release Agent config and all keys stored under the path of the cluster
+func (c *Core) GetClusterKeyTree(clusterID uint64) (*models.KeyTree, error) {
+	releaseAgentConfigKey := c.getReleaseAgentConfigKey(clusterID)
+	_, exists, err := c.getReleaseAgentConfig(releaseAgentConfigKey)
+	if err != nil {
+		return nil, fmt.Errorf("cannot find Release Agent config: %v", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("Release Agent config does not exist")
+	}
+
+	clusterPath := c.getClusterPath(clusterID)
+	keys, err := c.listKeysRecursively(clusterPath, "")
+	if err != nil {
+		return nil, fmt.Errorf("cannot list keys of cluster '%d': %v", clusterID, err)
+	}
+	return &models.KeyTree{
+		Root: clusterPath,
+		Keys: keys,
+	}, nil
+}
+
+// GetApplicationKeyTree - gets all keys stored under the path of an existing application
+// which are service configs and release plans
+func (c *Core) GetApplicationKeyTree(applicationID uint64) (*models.KeyTree, error) {
+	if _, err := c.GetApplication(applicationID); err != nil {
+		return nil, err
+	}
+	clusterPath := c.getClusterPath(*c.clusterID)
+	applicationPath := c.getApplicationPath(*c.clusterID, applicationID)
+	keyTree := &models.KeyTree{
+		Root: applicationPath,
+		Keys: make([]string, 0),
+	}
+	exists, err := c.directoryExists(clusterPath, path.Join("applications", strconv.FormatUint(applicationID, 10)))
+	if err != nil {
+		return nil, fmt.Errorf("cannot list keys of application '%d': %v", applicationID, err)
+	}
+	if !exists {
+		return keyTree, nil
+	}
+	if keyTree.Keys, err = c.listKeysRecursively(applicationPath, ""); err != nil {
+		return nil, fmt.Errorf("cannot list keys of application '%d': %v", applicationID, err)
+	}
+	return keyTree, nil
+}
+
+// DeleteKeyTree - deletes all keys of the key tree
+func (c *Core) DeleteKeyTree(keyTree models.KeyTree) error {
+	for _, key := range keyTree.Keys {
+		if err := c.kvClient.Delete(path.Join(keyTree.Root, key)); err != nil {
+			return fmt.Errorf("cannot delete key '%s': %v", path.Join(keyTree.Root, key), err)
+		}
+	}
+	return nil
+}
+
+// listEntries - lists names of keys and directories in a directory sorted by name
+// the key value store client lists directories without trailing slash, a trailing slash is removed anyway
+// so that every caller gets names which can be joined to paths and parsed as ids
+func (c *Core) listEntries(directoryPath string) ([]string, error) {
+	entries, err := c.kvClient.List(directoryPath)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list '%s': %v", directoryPath, err)
+	}
+	names := make([]string, len(entries))
+	for i, entry := range entries {
+		names[i] = strings.TrimSuffix(entry, "/")
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// listIDs - lists numeric ids of entries in a directory, entries which are not ids are ignored
+func (c *Core) listIDs(directoryPath string) ([]uint64, error) {
+	entries, err := c.listEntries(directoryPath)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]uint64, 0, len(entries))
+	seen := make(map[uint64]bool, len(entries))
+	for _, entry := range entries {
+		id, err := strconv.ParseUint(entry, 10, 64)
+		if err != nil || seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i] < ids[j]
+	})
+	return ids, nil
+}
+
+// containsEntry - checks whether sorted entries returned by listEntries contain the name
+func containsEntry(entries []string, name string) bool {
+	index := sort.SearchStrings(entries, name)
+	return index < len(entries) && entries[index] == name
+}
+
+// listKeysRecursively - lists keys below the root path relative to it
+// entries which exist as keys are not listed further, any other entry is a directory which is listed,
+// the first failure aborts listing so that a partial list is never returned
+func (c *Core) listKeysRecursively(rootPath, relativePath string) ([]string, error) {
+	entries, err := c.listEntries(path.Join(rootPath, relativePath))
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		entryPath := path.Join(relativePath, entry)
+		exists, err := c.kvClient.Exists(path.Join(rootPath, entryPath))
+		if err != nil {
+			return nil, fmt.Errorf("cannot find key '%s': %v", path.Join(rootPath, entryPath), err)
+		}
+		if exists {
+			keys = append(keys, entryPath)
+			continue
+		}
+		childKeys, err := c.listKeysRecursively(rootPath, entryPath)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, childKeys...)
+	}
+	return keys, nil
+}
+
+// directoryExists - checks whether a directory relative to an existing root directory is present
+// each parent is listed before its child so that a missing directory is never listed
+func (c *Core) directoryExists(rootPath, relativePath string) (bool, error) {
+	directoryPath := rootPath
+	for _, name := range strings.Split(relativePath, "/") {
+		entries, err := c.listEntries(directoryPath)
+		if err != nil {
+			return false, err
+		}
+		if !containsEntry(entries, name) {
+			return false, nil
+		}
+		directoryPath = path.Join(directoryPath, name)
+	}
+	return true, nil
+}
+
+// projectDirectoryExists - checks whether a directory relative to the project path is present
+// the project directory itself is missing until the first key of the project is stored
+func (c *Core) projectDirectoryExists(relativePath string) (bool, error) {
+	return c.directoryExists(path.Dir(c.projectPath), path.Join(path.Base(c.projectPath), relativePath))
+}
+
 // ListClusters - lists existing clusters
 func (c *Core) ListClusters() ([]models.ClusterView, error) {
 	clustersPath := path.Join(c.projectPath, "clusters")
-	clusterIDStrings, err := c.kvClient.List(clustersPath)
+	clusterIDStrings, err := c.listEntries(clustersPath)
 	if err != nil {
 		logging.Error("no clusters found: %v", err)
 		return nil, fmt.Errorf("no clusters found")
@@ -271,28 +471,171 @@ func (c *Core) GetCluster(clusterID uint64) (*models.ClusterView, error) {
 	}, nil
 }
 
-// PutApplication - puts application to existing Release Agent config
-func (c *Core) PutApplication(applicationID uint64, namespace string) error {
-	putApplication := func(releaseAgentConfig *models.ReleaseAgentConfig) {
-		for configNamespace, configApplicationID := range releaseAgentConfig.K8SNamespaceToApplicationID {
-			if configApplicationID == applicationID {
-				delete(releaseAgentConfig.K8SNamespaceToApplicationID, configNamespace)
+// PutApplication - puts application with its namespaces to existing Release Agent config
+// namespaces of the application which are not listed are removed,
+// namespaces used by other applications are reassigned only if force is set,
+// in which case the displaced applications are returned
+func (c *Core) PutApplication(applicationID uint64, namespaces []string, force bool) ([]models.DisplacedApplication, error) {
+	if len(namespaces) == 0 {
+		return nil, fmt.Errorf("at least one namespace must be provided")
+	}
+	var takenNamespaces map[uint64][]string
+	var releaseAgentConfig *models.ReleaseAgentConfig
+	putApplication := func(config *models.ReleaseAgentConfig) error {
+		var err error
+		takenNamespaces, err = assignNamespaces(config, applicationID, namespaces, force)
+		if err != nil {
+			return err
+		}
+		requested := make(map[string]bool, len(namespaces))
+		for _, namespace := range namespaces {
+			requested[namespace] = true
+		}
+		for configNamespace, configApplicationID := range config.K8SNamespaceToApplicationID {
+			if configApplicationID == applicationID && !requested[configNamespace] {
+				delete(config.K8SNamespaceToApplicationID, configNamespace)
+			}
+		}
+		releaseAgentConfig = config
+		return nil
+	}
+
+	if err := c.onReleaseAgentConfig(putApplication); err != nil {
+		return nil, err
+	}
+	return c.getDisplacedApplications(*releaseAgentConfig, takenNamespaces)
+}
+
+// AddApplicationNamespace - adds namespace to existing application
+// namespace used by another application is reassigned only if force is set,
+// in which case the displaced application is returned
+func (c *Core) AddApplicationNamespace(applicationID uint64, namespace string, force bool) ([]models.DisplacedApplication, error) {
+	var takenNamespaces map[uint64][]string
+	var releaseAgentConfig *models.ReleaseAgentConfig
+	addNamespace := func(config *models.ReleaseAgentConfig) error {
+		if len(config.GetApplicationNamespaces(applicationID)) == 0 {
+			return fmt.Errorf("application '%d' not found", applicationID)
+		}
+		var err error
+		takenNamespaces, err = assignNamespaces(config, applicationID, []string{namespace}, force)
+		if err != nil {
+			return err
+		}
+		releaseAgentConfig = config
+		return nil
+	}
+
+	if err := c.onReleaseAgentConfig(addNamespace); err != nil {
+		return nil, err
+	}
+	return c.getDisplacedApplications(*releaseAgentConfig, takenNamespaces)
+}
+
+// RemoveApplicationNamespace - removes namespace from application
+// the last namespace cannot be removed, the application has to be deleted instead
+func (c *Core) RemoveApplicationNamespace(applicationID uint64, namespace string) error {
+	removeNamespace := func(releaseAgentConfig *models.ReleaseAgentConfig) error {
+		if ownerID, exists := releaseAgentConfig.K8SNamespaceToApplicationID[namespace]; !exists || ownerID != applicationID {
+			return fmt.Errorf("namespace '%s' does not belong to application '%d'", namespace, applicationID)
+		}
+		if len(releaseAgentConfig.GetApplicationNamespaces(applicationID)) == 1 {
+			return fmt.Errorf("namespace '%s' is the last namespace of application '%d', delete the application instead", namespace, applicationID)
+		}
+		delete(releaseAgentConfig.K8SNamespaceToApplicationID, namespace)
+		return nil
+	}
+
+	return c.onReleaseAgentConfig(removeNamespace)
+}
+
+// assignNamespaces - assigns namespaces to application and returns namespaces taken from other applications by their ids
+func assignNamespaces(releaseAgentConfig *models.ReleaseAgentConfig, applicationID uint64, namespaces []string, force bool) (map[uint64][]string, error) {
+	if releaseAgentConfig.K8SNamespaceToApplicationID == nil {
+		releaseAgentConfig.K8SNamespaceToApplicationID = make(map[string]uint64)
+	}
+	takenNamespaces := make(map[uint64][]string)
+	seen := make(map[string]bool, len(namespaces))
+	for _, namespace := range namespaces {
+		if namespace == "" {
+			return nil, fmt.Errorf("namespace must not be empty")
+		}
+		if seen[namespace] {
+			continue
+		}
+		seen[namespace] = true
+		if ownerID, exists := releaseAgentConfig.K8SNamespaceToApplicationID[namespace]; exists && ownerID != applicationID {
+			if !force {
+				return nil, fmt.Errorf("namespace '%s' is already used by application '%d', use --force to reassign it", namespace, ownerID)
 			}
+			takenNamespaces[ownerID] = append(takenNamespaces[ownerID], namespace)
 		}
+	}
+	for _, namespace := range namespaces {
 		releaseAgentConfig.K8SNamespaceToApplicationID[namespace] = applicationID
 	}
+	return takenNamespaces, nil
+}
+
+// getDisplacedApplications - describes applications which lost namespaces
+// service configs and release plans are listed for applications which have no namespace left,
+// the namespaces are already reassigned when listing fails so the error says so
+func (c *Core) getDisplacedApplications(releaseAgentConfig models.ReleaseAgentConfig, takenNamespaces map[uint64][]string) ([]models.DisplacedApplication, error) {
+	displacedApplications := make([]models.DisplacedApplication, 0, len(takenNamespaces))
+	for applicationID, namespaces := range takenNamespaces {
+		displacedApplication := models.DisplacedApplication{
+			ID:         applicationID,
+			Namespaces: namespaces,
+			Orphaned:   len(releaseAgentConfig.GetApplicationNamespaces(applicationID)) == 0,
+			Path:       c.getApplicationPath(*c.clusterID, applicationID),
+		}
+		if displacedApplication.Orphaned {
+			if err := c.listDisplacedApplicationKeys(&displacedApplication); err != nil {
+				return nil, fmt.Errorf("namespaces are reassigned but keys of displaced application '%d' cannot be listed: %v", applicationID, err)
+			}
+		}
+		displacedApplications = append(displacedApplications, displacedApplication)
+	}
+	sort.Slice(displacedApplications, func(i, j int) bool {
+		return displacedApplications[i].ID < displacedApplications[j].ID
+	})
+	return displacedApplications, nil
+}
 
-	return c.onReleaseAgentConfig(putApplication)
+// listDisplacedApplicationKeys - lists service configs and release plans left under the path of an orphaned application
+// only missing directories are skipped
+func (c *Core) listDisplacedApplicationKeys(displacedApplication *models.DisplacedApplication) error {
+	clusterPath := c.getClusterPath(*c.clusterID)
+	applicationPath := path.Join("applications", strconv.FormatUint(displacedApplication.ID, 10))
+	exists, err := c.directoryExists(clusterPath, path.Join(applicationPath, "service-configs"))
+	if err != nil {
+		return err
+	}
+	if exists {
+		if displacedApplication.ServiceConfigIDs, err = c.listServiceIDs(*c.clusterID, displacedApplication.ID); err != nil {
+			return err
+		}
+	}
+	exists, err = c.directoryExists(clusterPath, path.Join(applicationPath, "release-plans"))
+	if err != nil {
+		return err
+	}
+	if exists {
+		if displacedApplication.ReleasePlanServiceIDs, err = c.listReleasePlanServiceIDs(*c.clusterID, displacedApplication.ID); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // DeleteApplication - deletes application from Release Agent config
 func (c *Core) DeleteApplication(applicationID uint64) error {
-	deleteApplication := func(releaseAgentConfig *models.ReleaseAgentConfig) {
+	deleteApplication := func(releaseAgentConfig *models.ReleaseAgentConfig) error {
 		for configNamespace, configApplicationID := range releaseAgentConfig.K8SNamespaceToApplicationID {
 			if configApplicationID == applicationID {
 				delete(releaseAgentConfig.K8SNamespaceToApplicationID, configNamespace)
 			}
 		}
+		return nil
 	}
 
 	return c.onReleaseAgentConfig(deleteApplication)
@@ -303,7 +646,11 @@ func (c *Core) ListApplications() ([]models.ApplicationView, error) {
 	if c.clusterID == nil {
 		return nil, fmt.Errorf("cluster id must be provided")
 	}
-	releaseAgentConfigKey := c.getReleaseAgentConfigKey(uint64(*c.clusterID))
+	return c.listApplications(*c.clusterID)
+}
+
+func (c *Core) listApplications(clusterID uint64) ([]models.ApplicationView, error) {
+	releaseAgentConfigKey := c.getReleaseAgentConfigKey(clusterID)
 	releaseAgentConfig, exists, err := c.getReleaseAgentConfig(releaseAgentConfigKey)
 	if err != nil {
 		return nil, fmt.Errorf("cannot find Release Agent config: %v", err)
@@ -311,17 +658,7 @@ func (c *Core) ListApplications() ([]models.ApplicationView, error) {
 	if !exists {
 		return nil, fmt.Errorf("Release Agent config does not exist")
 	}
-	applications := make([]models.ApplicationView, len(releaseAgentConfig.K8SNamespaceToApplicationID))
-	i := 0
-	for namespace, applicationID := range releaseAgentConfig.K8SNamespaceToApplicationID {
-		applications[i] = models.ApplicationView{
-			ID:        applicationID,
-			Namespace: namespace,
-		}
-		i++
-	}
-
-	return applications, nil
+	return releaseAgentConfig.GetApplications(), nil
 }
 
 // GetApplication - gets existing application
@@ -338,20 +675,24 @@ func (c *Core) GetApplication(applicationID uint64) (*models.ApplicationView, er
 		return nil, fmt.Errorf("Release Agent config does not exist")
 	}
 
-	for namespace, configApplicationID := range releaseAgentConfig.K8SNamespaceToApplicationID {
-		if applicationID == configApplicationID {
-			return &models.ApplicationView{
-				ID:        applicationID,
-				Namespace: namespace,
-			}, nil
-		}
+	namespaces := releaseAgentConfig.GetApplicationNamespaces(applicationID)
+	if len(namespaces) > 0 {
+		view := models.NewApplicationView(applicationID, namespaces)
+		return &view, nil
 	}
 
 	return nil, fmt.Errorf("application '%d' not found", applicationID)
 }
 
 // PutServiceConfig - puts service to key value store
-func (c *Core) PutServiceConfig(serviceConfigText string) error {
+// ingress rules already claimed by other services of the cluster are rejected unless sharing is allowed
+func (c *Core) PutServiceConfig(serviceConfigText string, allowShared bool) error {
+	return c.putServiceConfig(serviceConfigText, allowShared, nil)
+}
+
+// putServiceConfig - validates and puts service config, beforePut is called with the service config key
+// right before the put so that callers can check the stored service config once more
+func (c *Core) putServiceConfig(serviceConfigText string, allowShared bool, beforePut func(serviceConfigKey string) error) error {
 	if c.clusterID == nil {
 		return fmt.Errorf("cluster id must be provided")
 	}
@@ -366,8 +707,32 @@ func (c *Core) PutServiceConfig(serviceConfigText string) error {
 	if err := serviceConfig.Validate(); err != nil {
 		return fmt.Errorf("service config validation failed: %v", err)
 	}
+	resolved, err := c.resolveServiceConfigPolicyNames(&serviceConfig)
+	if err != nil {
+		return fmt.Errorf("service config validation failed: %v", err)
+	}
+	if resolved {
+		serviceConfigBytes, err := json.Marshal(serviceConfig)
+		if err != nil {
+			return fmt.Errorf("cannot serialize service config: %v", err)
+		}
+		serviceConfigText = string(serviceConfigBytes)
+	}
+	if err := c.checkServiceConfigPolicies(serviceConfig); err != nil {
+		return fmt.Errorf("service config validation failed: %v", err)
+	}
+	if !allowShared {
+		if err := c.checkIngressConflicts(*c.clusterID, serviceConfig); err != nil {
+			return fmt.Errorf("service config validation failed: %v", err)
+		}
+	}
 
 	serviceConfigKey := c.getServiceConfigKey(*c.clusterID, *serviceConfig.ApplicationID, *serviceConfig.ServiceID)
+	if beforePut != nil {
+		if err := beforePut(serviceConfigKey); err != nil {
+			return err
+		}
+	}
 
 	return c.kvClient.Put(serviceConfigKey, serviceConfigText)
 }
@@ -391,12 +756,44 @@ func (c *Core) DeleteServiceConfig(serviceID, applicationID uint64) error {
 }
 
 // ListServices - lists existing services from key value store
-func (c *Core) ListServices(applicationID uint64) ([]uint64, error) {
+// service configs are fetched concurrently, services are sorted by id
+func (c *Core) ListServices(applicationID uint64) ([]models.ServiceView, error) {
 	if c.clusterID == nil {
 		return nil, fmt.Errorf("cluster id must be provided")
 	}
-	serviceConfigsPath := c.getServiceConfigsPath(*c.clusterID, applicationID)
-	serviceConfigsKeys, err := c.kvClient.List(serviceConfigsPath)
+	clusterID := *c.clusterID
+	serviceIDs, err := c.listServiceIDs(clusterID, applicationID)
+	if err != nil {
+		return nil, err
+	}
+
+	services := make([]models.ServiceView, len(serviceIDs))
+	group := newFanOut(maxConcurrentRequests)
+	for i, serviceID := range serviceIDs {
+		i, serviceID := i, serviceID
+		group.Go(func() {
+			serviceConfig, err := c.getServiceConfig(clusterID, applicationID, serviceID)
+			if err != nil {
+				services[i] = models.ServiceView{
+					ID:    serviceID,
+					Error: fmt.Sprintf("cannot get service config: %v", err),
+				}
+				return
+			}
+			services[i] = models.NewServiceView(serviceID, *serviceConfig)
+		})
+	}
+	group.Wait()
+
+	sort.Slice(services, func(i, j int) bool {
+		return services[i].ID < services[j].ID
+	})
+	return services, nil
+}
+
+func (c *Core) listServiceIDs(clusterID, applicationID uint64) ([]uint64, error) {
+	serviceConfigsPath := c.getServiceConfigsPath(clusterID, applicationID)
+	serviceConfigsKeys, err := c.listEntries(serviceConfigsPath)
 	if err != nil {
 		logging.Error("no services found: %v", err)
 		return nil, fmt.Errorf("no services found")
@@ -415,6 +812,26 @@ func (c *Core) ListServices(applicationID uint64) ([]uint64, error) {
 	return serviceIDs, nil
 }
 
+// listReleasePlanServiceIDs - lists ids of services having release plans in the application
+func (c *Core) listReleasePlanServiceIDs(clusterID, applicationID uint64) ([]uint64, error) {
+	releasePlansPath := path.Join(c.getApplicationPath(clusterID, applicationID), "release-plans")
+	releasePlanKeys, err := c.listEntries(releasePlansPath)
+	if err != nil {
+		return nil, fmt.Errorf("no release plans found")
+	}
+
+	serviceIDs := make([]uint64, 0, len(releasePlanKeys))
+	for _, releasePlanKey := range releasePlanKeys {
+		serviceID, err := strconv.ParseUint(releasePlanKey, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("found release plans of service with invalid id: '%s'", releasePlanKey)
+		}
+		serviceIDs = append(serviceIDs, serviceID)
+	}
+
+	return serviceIDs, nil
+}
+
 // GetServiceConfigText - gets service config json text from key value store
 func (c *Core) GetServiceConfigText(serviceID, applicationID uint64) (string, error) {
 	if c.clusterID == nil {
@@ -433,7 +850,27 @@ func (c *Core) GetServiceConfigText(serviceID, applicationID uint64) (string, er
 	return c.kvClient.Get(serviceConfigKey)
 }
 
-func (c *Core) onReleaseAgentConfig(apply func(*models.ReleaseAgentConfig)) error {
+func (c *Core) getServiceConfig(clusterID, applicationID, serviceID uint64) (*models.ServiceConfig, error) {
+	serviceConfigKey := c.getServiceConfigKey(clusterID, applicationID, serviceID)
+	exists, err := c.kvClient.Exists(serviceConfigKey)
+	if err != nil {
+		return nil, fmt.Errorf("cannot find service config: %v", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("service config does not exist")
+	}
+	serviceConfigText, err := c.kvClient.Get(serviceConfigKey)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get service config: %v", err)
+	}
+	var serviceConfig models.ServiceConfig
+	if err := json.Unmarshal([]byte(serviceConfigText), &serviceConfig); err != nil {
+		return nil, fmt.Errorf("cannot deserialize service config: %v", err)
+	}
+	return &serviceConfig, nil
+}
+
+func (c *Core) onReleaseAgentConfig(apply func(*models.ReleaseAgentConfig) error) error {
 	if c.clusterID == nil {
 		return fmt.Errorf("cluster id must be provided")
 	}
@@ -446,7 +883,9 @@ func (c *Core) onReleaseAgentConfig(apply func(*models.ReleaseAgentConfig)) erro
 		return fmt.Errorf("Release Agent config does not exist. Please create cluster first")
 	}
 
-	apply(releaseAgentConfig)
+	if err := apply(releaseAgentConfig); err != nil {
+		return err
+	}
 
 	return c.saveReleaseAgentConfig(releaseAgentConfigKey, *releaseAgentConfig)
 }
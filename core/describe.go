@@ -0,0 +1,211 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/magneticio/forklift/models"
+	"github.com/magneticio/vamp-policies/policy/interface/api"
+)
+
+// DescribeCluster - describes cluster with all its applications, services and their latest release plans
+// everything beneath the cluster is fetched concurrently
+func (c *Core) DescribeCluster(clusterID uint64) (*models.ClusterDescription, error) {
+	cluster, err := c.GetCluster(clusterID)
+	if err != nil {
+		return nil, err
+	}
+	applications, err := c.listApplications(clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	description := &models.ClusterDescription{
+		ID:                   cluster.ID,
+		Name:                 cluster.Name,
+		NatsChannel:          cluster.NatsChannel,
+		OptimiserNatsChannel: cluster.OptimiserNatsChannel,
+		Applications:         make([]models.ApplicationDescription, len(applications)),
+	}
+	d := c.newDescriber()
+	for i, application := range applications {
+		description.Applications[i] = models.ApplicationDescription{
+			ID:         application.ID,
+			Namespaces: application.Namespaces,
+		}
+		d.describeApplication(clusterID, &description.Applications[i])
+	}
+	if err := d.wait(); err != nil {
+		return nil, err
+	}
+
+	return description, nil
+}
+
+// DescribeApplication - describes application with all its services and their latest release plans
+// everything beneath the application is fetched concurrently
+func (c *Core) DescribeApplication(applicationID uint64) (*models.ApplicationDescription, error) {
+	application, err := c.GetApplication(applicationID)
+	if err != nil {
+		return nil, err
+	}
+
+	description := &models.ApplicationDescription{
+		ID:         application.ID,
+		Namespaces: application.Namespaces,
+	}
+	d := c.newDescriber()
+	d.describeApplication(*c.clusterID, description)
+	if err := d.wait(); err != nil {
+		return nil, err
+	}
+
+	return description, nil
+}
+
+// describer - fetches parts of descriptions concurrently, each goroutine fills its own part of the description
+// policy names and types are fetched once and filled in after all services are described
+type describer struct {
+	core        *Core
+	group       *fanOut
+	mutex       sync.Mutex
+	services    []*models.ServiceDescription
+	policyNames map[uint64]string
+	policyTypes map[uint64]api.PolicyType
+	policyErr   error
+}
+
+func (c *Core) newDescriber() *describer {
+	d := &describer{
+		core:  c,
+		group: newFanOut(maxConcurrentRequests),
+	}
+	d.group.Go(func() {
+		d.policyNames = c.getPolicyNamesByID()
+	})
+	d.group.Go(func() {
+		d.policyTypes, d.policyErr = c.getPolicyTypes()
+	})
+	return d
+}
+
+func (d *describer) describeApplication(clusterID uint64, application *models.ApplicationDescription) {
+	applicationID := application.ID
+	d.group.Go(func() {
+		application.Services = make([]models.ServiceDescription, 0)
+		exists, err := d.core.directoryExists(d.core.getClusterPath(clusterID), path.Join("applications", strconv.FormatUint(applicationID, 10), "service-configs"))
+		if err != nil {
+			application.Errors = append(application.Errors, fmt.Sprintf("cannot list services: %v", err))
+			return
+		}
+		if !exists {
+			return
+		}
+		serviceIDs, err := d.core.listServiceIDs(clusterID, applicationID)
+		if err != nil {
+			application.Errors = append(application.Errors, fmt.Sprintf("cannot list services: %v", err))
+			return
+		}
+		application.Services = make([]models.ServiceDescription, len(serviceIDs))
+		for i, serviceID := range serviceIDs {
+			service := &application.Services[i]
+			service.ID = serviceID
+			d.mutex.Lock()
+			d.services = append(d.services, service)
+			d.mutex.Unlock()
+			d.describeService(clusterID, applicationID, service)
+		}
+	})
+}
+
+func (d *describer) describeService(clusterID, applicationID uint64, service *models.ServiceDescription) {
+	d.group.Go(func() {
+		serviceConfig, err := d.core.getServiceConfig(clusterID, applicationID, service.ID)
+		if err != nil {
+			d.reportError(service, fmt.Errorf("cannot get service config: %v", err))
+			return
+		}
+		view := models.NewServiceView(service.ID, *serviceConfig)
+		service.Namespace = view.Namespace
+		service.Labels = view.Labels
+		service.IngressDomains = view.IngressDomains
+		for _, reference := range serviceConfig.PolicyReferences() {
+			service.Policies = append(service.Policies, models.PolicyDescription{
+				Slot: reference.Slot,
+				ID:   reference.PolicyID,
+			})
+		}
+	})
+
+	d.group.Go(func() {
+		releasePlan, err := d.core.getLatestReleasePlanDescription(clusterID, applicationID, service.ID)
+		if err != nil {
+			d.reportError(service, err)
+			return
+		}
+		service.LatestReleasePlan = releasePlan
+	})
+}
+
+func (d *describer) reportError(service *models.ServiceDescription, err error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	service.Errors = append(service.Errors, err.Error())
+}
+
+// wait - waits for all parts of descriptions and fills in policy names and types
+func (d *describer) wait() error {
+	d.group.Wait()
+	if d.policyErr != nil {
+		return d.policyErr
+	}
+	for _, service := range d.services {
+		sort.Strings(service.Errors)
+		for i := range service.Policies {
+			policy := &service.Policies[i]
+			policyType, exists := d.policyTypes[policy.ID]
+			policy.Name = d.policyNames[policy.ID]
+			policy.Type = string(policyType)
+			policy.Missing = !exists
+		}
+	}
+	return nil
+}
+
+// getLatestReleasePlanDescription - gets status of the release plan of the latest service version
+// nil is returned only if the service has no release plans directory, listing failures are returned
+func (c *Core) getLatestReleasePlanDescription(clusterID, applicationID, serviceID uint64) (*models.ReleasePlanDescription, error) {
+	relativePath := path.Join("applications", strconv.FormatUint(applicationID, 10), "release-plans", strconv.FormatUint(serviceID, 10))
+	exists, err := c.directoryExists(c.getClusterPath(clusterID), relativePath)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list release plans: %v", err)
+	}
+	if !exists {
+		return nil, nil
+	}
+	releasePlansPath := path.Join(c.getClusterPath(clusterID), relativePath)
+	releasePlanKeys, err := c.listEntries(releasePlansPath)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list release plans: %v", err)
+	}
+	latestVersion, latestVersionText := models.GetLatestServiceVersion(releasePlanKeys)
+	if latestVersion == nil {
+		return nil, nil
+	}
+	releasePlanText, err := c.kvClient.Get(path.Join(releasePlansPath, latestVersionText))
+	if err != nil {
+		return nil, fmt.Errorf("cannot get release plan '%s': %v", latestVersionText, err)
+	}
+	var releasePlan models.ReleasePlan
+	if err := json.Unmarshal([]byte(releasePlanText), &releasePlan); err != nil {
+		return nil, fmt.Errorf("cannot deserialize release plan '%s': %v", latestVersionText, err)
+	}
+	return &models.ReleasePlanDescription{
+		Version: latestVersionText,
+		Status:  releasePlan.Status,
+	}, nil
+}
@@ -0,0 +1,101 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/magneticio/forklift/models"
+)
+
+func TestDescribeCluster(t *testing.T) {
+	store := memoryKeyValueStore{
+		"vamp/projects/1/clusters/1/release-agent-config":                         `{"cluster_name":"test","applications":{"shop":2,"shop-canary":2}}`,
+		"vamp/projects/1/clusters/1/applications/2/service-configs/5":             `{"application_id":2,"service_id":5,"k8s_namespace":"shop","k8s_labels":{"app":"shop"},"default_policy_id":1,"ingress_rules":[{"domain":"shop.local","path":"/api","port":80}]}`,
+		"vamp/projects/1/clusters/1/applications/2/service-configs/6":             `invalid`,
+		"vamp/projects/1/clusters/1/applications/2/release-plans/5/1.0.0":         `{"status":"finished"}`,
+		"vamp/projects/1/clusters/1/applications/2/release-plans/5/1.2.0":         `{"status":"running"}`,
+		"vamp/projects/1/clusters/1/applications/2/release-plans/5/not-a-version": `{"status":"unknown"}`,
+	}
+	core := newMemoryCore(store, nil)
+
+	description, err := core.DescribeCluster(1)
+	if err != nil {
+		t.Fatalf("DescribeCluster() error = %v", err)
+	}
+	want := &models.ClusterDescription{
+		ID:   1,
+		Name: "test",
+		Applications: []models.ApplicationDescription{
+			{
+				ID:         2,
+				Namespaces: []string{"shop", "shop-canary"},
+				Services: []models.ServiceDescription{
+					{
+						ID:                5,
+						Namespace:         "shop",
+						Labels:            map[string]string{"app": "shop"},
+						IngressDomains:    []string{"shop.local/api"},
+						Policies:          []models.PolicyDescription{{Slot: "default", ID: 1, Missing: true}},
+						LatestReleasePlan: &models.ReleasePlanDescription{Version: "1.2.0", Status: "running"},
+					},
+					{
+						ID:     6,
+						Errors: []string{"cannot get service config: cannot deserialize service config: invalid character 'i' looking for beginning of value"},
+					},
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(description, want) {
+		t.Errorf("DescribeCluster() = %+v, want %+v", description, want)
+	}
+}
+
+func TestDescribeClusterReportsListingFailures(t *testing.T) {
+	store := failingListKeyValueStore{
+		memoryKeyValueStore: memoryKeyValueStore{
+			"vamp/projects/1/clusters/1/release-agent-config":                 `{"cluster_name":"test","applications":{"shop":2,"cart":3,"empty":4}}`,
+			"vamp/projects/1/clusters/1/applications/2/service-configs/5":     `{"application_id":2,"service_id":5,"k8s_namespace":"shop","k8s_labels":{"app":"shop"},"default_policy_id":1}`,
+			"vamp/projects/1/clusters/1/applications/2/release-plans/5/1.0.0": `{"status":"finished"}`,
+			"vamp/projects/1/clusters/1/applications/3/service-configs/7":     `{}`,
+		},
+		failingDirectory: "vamp/projects/1/clusters/1/applications/3/service-configs",
+	}
+	core := &Core{kvClient: strictListKeyValueStore{store.memoryKeyValueStore}, projectPath: "vamp/projects/1"}
+
+	description, err := core.DescribeCluster(1)
+	if err != nil {
+		t.Fatalf("DescribeCluster() of applications without directories error = %v", err)
+	}
+	for _, application := range description.Applications {
+		if len(application.Errors) != 0 {
+			t.Errorf("DescribeCluster() application '%d' errors = %v, want none", application.ID, application.Errors)
+		}
+	}
+
+	core.kvClient = store
+	description, err = core.DescribeCluster(1)
+	if err != nil {
+		t.Fatalf("DescribeCluster() error = %v", err)
+	}
+	for _, application := range description.Applications {
+		if failed := len(application.Errors) != 0; failed != (application.ID == 3) {
+			t.Errorf("DescribeCluster() application '%d' errors = %v, want errors only for application 3", application.ID, application.Errors)
+		}
+	}
+
+	store.failingDirectory = "vamp/projects/1/clusters/1/applications/2/release-plans/5"
+	core.kvClient = store
+	description, err = core.DescribeCluster(1)
+	if err != nil {
+		t.Fatalf("DescribeCluster() error = %v", err)
+	}
+	for _, application := range description.Applications {
+		if application.ID != 2 {
+			continue
+		}
+		if service := application.Services[0]; service.LatestReleasePlan != nil || len(service.Errors) != 1 {
+			t.Errorf("DescribeCluster() service = %+v, want release plan listing error", service)
+		}
+	}
+}
@@ -0,0 +1,202 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"strconv"
+
+	"github.com/magneticio/forklift/models"
+)
+
+// CheckProject - walks all clusters of the project and reports clusters without config,
+// service configs and release plans left without application or service and invalid service configs
+func (c *Core) CheckProject() ([]models.ProjectIssue, error) {
+	issues := make([]models.ProjectIssue, 0)
+	exists, err := c.projectDirectoryExists("clusters")
+	if err != nil {
+		return nil, fmt.Errorf("cannot list clusters: %v", err)
+	}
+	if !exists {
+		return issues, nil
+	}
+	clusterIDs, err := c.listIDs(path.Join(c.projectPath, "clusters"))
+	if err != nil {
+		return nil, fmt.Errorf("cannot list clusters: %v", err)
+	}
+
+	for _, clusterID := range clusterIDs {
+		clusterIssues, err := c.checkCluster(clusterID)
+		if err != nil {
+			return nil, err
+		}
+		issues = append(issues, clusterIssues...)
+	}
+	return issues, nil
+}
+
+// GetProjectIssueKeyTree - gets keys which are deleted to fix an issue,
+// a cluster without config is fixed by deleting all keys stored under the path of the cluster
+func (c *Core) GetProjectIssueKeyTree(issue models.ProjectIssue) (*models.KeyTree, error) {
+	if !issue.Fixable {
+		return nil, fmt.Errorf("%s '%s' cannot be fixed automatically", issue.Kind, issue.Key)
+	}
+	if issue.Kind == models.ClusterWithoutConfigIssue {
+		keys, err := c.listKeysRecursively(issue.Key, "")
+		if err != nil {
+			return nil, fmt.Errorf("cannot list keys of '%s': %v", issue.Key, err)
+		}
+		return &models.KeyTree{
+			Root: issue.Key,
+			Keys: keys,
+		}, nil
+	}
+	return &models.KeyTree{
+		Root: path.Dir(issue.Key),
+		Keys: []string{path.Base(issue.Key)},
+	}, nil
+}
+
+// FixProjectIssue - deletes keys of a fixable issue
+func (c *Core) FixProjectIssue(issue models.ProjectIssue) error {
+	keyTree, err := c.GetProjectIssueKeyTree(issue)
+	if err != nil {
+		return err
+	}
+	return c.DeleteKeyTree(*keyTree)
+}
+
+func (c *Core) checkCluster(clusterID uint64) ([]models.ProjectIssue, error) {
+	releaseAgentConfig, exists, err := c.getReleaseAgentConfig(c.getReleaseAgentConfigKey(clusterID))
+	if err != nil {
+		return nil, fmt.Errorf("cannot get cluster '%d': %v", clusterID, err)
+	}
+	if !exists {
+		return []models.ProjectIssue{{
+			Kind:    models.ClusterWithoutConfigIssue,
+			Key:     c.getClusterPath(clusterID),
+			Message: "cluster directory has no release-agent-config",
+			Fixable: true,
+		}}, nil
+	}
+
+	mappedApplications := make(map[uint64]bool)
+	for _, applicationID := range releaseAgentConfig.K8SNamespaceToApplicationID {
+		mappedApplications[applicationID] = true
+	}
+
+	issues := make([]models.ProjectIssue, 0)
+	exists, err = c.directoryExists(c.getClusterPath(clusterID), "applications")
+	if err != nil {
+		return nil, fmt.Errorf("cannot list applications of cluster '%d': %v", clusterID, err)
+	}
+	if !exists {
+		return issues, nil
+	}
+	applicationIDs, err := c.listIDs(path.Join(c.getClusterPath(clusterID), "applications"))
+	if err != nil {
+		return nil, fmt.Errorf("cannot list applications of cluster '%d': %v", clusterID, err)
+	}
+	for _, applicationID := range applicationIDs {
+		applicationIssues, err := c.checkApplication(clusterID, applicationID, mappedApplications[applicationID])
+		if err != nil {
+			return nil, err
+		}
+		issues = append(issues, applicationIssues...)
+	}
+	return issues, nil
+}
+
+// checkApplication - checks service configs and release plans of an application,
+// only directories present in the application directory are listed
+func (c *Core) checkApplication(clusterID, applicationID uint64, mapped bool) ([]models.ProjectIssue, error) {
+	issues := make([]models.ProjectIssue, 0)
+	applicationPath := c.getApplicationPath(clusterID, applicationID)
+	exists, err := c.directoryExists(applicationPath, "service-configs")
+	if err != nil {
+		return nil, fmt.Errorf("cannot list service configs of application '%d': %v", applicationID, err)
+	}
+	serviceIDs := make([]uint64, 0)
+	if exists {
+		if serviceIDs, err = c.listIDs(c.getServiceConfigsPath(clusterID, applicationID)); err != nil {
+			return nil, fmt.Errorf("cannot list service configs of application '%d': %v", applicationID, err)
+		}
+	}
+	services := make(map[uint64]bool, len(serviceIDs))
+	for _, serviceID := range serviceIDs {
+		services[serviceID] = true
+		serviceConfigKey := c.getServiceConfigKey(clusterID, applicationID, serviceID)
+		if !mapped {
+			issues = append(issues, models.ProjectIssue{
+				Kind:    models.OrphanedServiceConfigIssue,
+				Key:     serviceConfigKey,
+				Message: fmt.Sprintf("application '%d' is not mapped to any namespace", applicationID),
+				Fixable: true,
+			})
+			continue
+		}
+		if err := c.validateStoredServiceConfig(serviceConfigKey); err != nil {
+			issues = append(issues, models.ProjectIssue{
+				Kind:    models.InvalidServiceConfigIssue,
+				Key:     serviceConfigKey,
+				Message: err.Error(),
+			})
+		}
+	}
+
+	exists, err = c.directoryExists(applicationPath, "release-plans")
+	if err != nil {
+		return nil, fmt.Errorf("cannot list release plans of application '%d': %v", applicationID, err)
+	}
+	if !exists {
+		return issues, nil
+	}
+	releasePlansPath := path.Join(applicationPath, "release-plans")
+	releasePlanServiceIDs, err := c.listIDs(releasePlansPath)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list release plans of application '%d': %v", applicationID, err)
+	}
+	for _, serviceID := range releasePlanServiceIDs {
+		var message string
+		switch {
+		case !mapped:
+			message = fmt.Sprintf("application '%d' is not mapped to any namespace", applicationID)
+		case !services[serviceID]:
+			message = fmt.Sprintf("service config '%d' does not exist", serviceID)
+		default:
+			continue
+		}
+		servicePlansPath := path.Join(releasePlansPath, strconv.FormatUint(serviceID, 10))
+		versions, err := c.listKeysRecursively(servicePlansPath, "")
+		if err != nil {
+			return nil, fmt.Errorf("cannot list release plans of service '%d': %v", serviceID, err)
+		}
+		for _, version := range versions {
+			issues = append(issues, models.ProjectIssue{
+				Kind:    models.OrphanedReleasePlanIssue,
+				Key:     path.Join(servicePlansPath, version),
+				Message: message,
+				Fixable: true,
+			})
+		}
+	}
+	return issues, nil
+}
+
+func (c *Core) validateStoredServiceConfig(serviceConfigKey string) error {
+	serviceConfigText, err := c.kvClient.Get(serviceConfigKey)
+	if err != nil {
+		return fmt.Errorf("cannot get service config: %v", err)
+	}
+	var serviceConfig models.ServiceConfig
+	if err := json.Unmarshal([]byte(serviceConfigText), &serviceConfig); err != nil {
+		return fmt.Errorf("cannot deserialize service config: %v", err)
+	}
+	if err := models.NewValidateDTO()(serviceConfig); err != nil {
+		return fmt.Errorf("service config validation failed: %v", err)
+	}
+	if err := serviceConfig.Validate(); err != nil {
+		return fmt.Errorf("service config validation failed: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/magneticio/forklift/models"
+)
+
+const doctorTestServiceConfig = `{"application_id":2,"service_id":5,"k8s_namespace":"shop","k8s_labels":{"app":"shop"},"version_selector":"version","default_policy_id":1}`
+
+func TestCheckProject(t *testing.T) {
+	store := memoryKeyValueStore{
+		"vamp/projects/1/clusters/1/release-agent-config":                                 `{"applications":{"shop":2}}`,
+		"vamp/projects/1/clusters/1/applications/2/service-configs/5":                     doctorTestServiceConfig,
+		"vamp/projects/1/clusters/1/applications/2/service-configs/6":                     `{"application_id":2}`,
+		"vamp/projects/1/clusters/1/applications/2/release-plans/5/1.0.0":                 `{}`,
+		"vamp/projects/1/clusters/1/applications/2/release-plans/7/1.0.0":                 `{}`,
+		"vamp/projects/1/clusters/1/applications/3/service-configs/5":                     doctorTestServiceConfig,
+		"vamp/projects/1/clusters/9/applications/2/service-configs/5":                     doctorTestServiceConfig,
+		"vamp/projects/1/clusters/9/applications/2/release-plans/5/1.0.0":                 `{}`,
+		"vamp/projects/1/clusters/1/applications/2/release-plans/not-a-service/1.0.0":     `{}`,
+		"vamp/projects/1/clusters/not-a-cluster/applications/2/service-configs/5/invalid": `{}`,
+	}
+	core := newMemoryCore(store, nil)
+
+	issues, err := core.CheckProject()
+	if err != nil {
+		t.Fatalf("CheckProject() error = %v", err)
+	}
+	kinds := make([]string, len(issues))
+	keys := make([]string, len(issues))
+	for i, issue := range issues {
+		kinds[i] = issue.Kind
+		keys[i] = issue.Key
+	}
+	wantKinds := []string{
+		models.InvalidServiceConfigIssue,
+		models.OrphanedReleasePlanIssue,
+		models.OrphanedServiceConfigIssue,
+		models.ClusterWithoutConfigIssue,
+	}
+	wantKeys := []string{
+		"vamp/projects/1/clusters/1/applications/2/service-configs/6",
+		"vamp/projects/1/clusters/1/applications/2/release-plans/7/1.0.0",
+		"vamp/projects/1/clusters/1/applications/3/service-configs/5",
+		"vamp/projects/1/clusters/9",
+	}
+	if !reflect.DeepEqual(kinds, wantKinds) || !reflect.DeepEqual(keys, wantKeys) {
+		t.Fatalf("CheckProject() = %v, want kinds %v with keys %v", issues, wantKinds, wantKeys)
+	}
+
+	for _, issue := range issues {
+		if issue.Fixable {
+			if err := core.FixProjectIssue(issue); err != nil {
+				t.Fatalf("FixProjectIssue() error = %v", err)
+			}
+		}
+	}
+	if err := core.FixProjectIssue(issues[0]); err == nil {
+		t.Errorf("FixProjectIssue() of invalid service config should fail")
+	}
+	for _, key := range wantKeys[1:] {
+		if _, exists := store[key]; exists {
+			t.Errorf("key '%s' should be deleted", key)
+		}
+	}
+	if len(store) != 6 {
+		t.Errorf("store has %d keys after fixing, want 6", len(store))
+	}
+}
+
+func TestCheckProjectFailsWhenDirectoryCannotBeListed(t *testing.T) {
+	store := failingListKeyValueStore{
+		memoryKeyValueStore: memoryKeyValueStore{
+			"vamp/projects/1/clusters/1/release-agent-config":             `{"applications":{"shop":2}}`,
+			"vamp/projects/1/clusters/1/applications/3/service-configs/5": doctorTestServiceConfig,
+		},
+		failingDirectory: "vamp/projects/1/clusters/1/applications/3/service-configs",
+	}
+	core := &Core{kvClient: store, projectPath: "vamp/projects/1"}
+
+	if issues, err := core.CheckProject(); err == nil {
+		t.Errorf("CheckProject() = %v, want error", issues)
+	}
+}
+
+func TestCheckProjectWithMissingDirectories(t *testing.T) {
+	store := strictListKeyValueStore{memoryKeyValueStore{
+		"vamp/projects/2/policies/1": `{}`,
+	}}
+	core := &Core{kvClient: store, projectPath: "vamp/projects/1"}
+
+	if issues, err := core.CheckProject(); err != nil || len(issues) != 0 {
+		t.Errorf("CheckProject() of empty project = %v, %v, want no issues", issues, err)
+	}
+
+	store.memoryKeyValueStore["vamp/projects/1/clusters/1/release-agent-config"] = `{"applications":{"shop":2}}`
+	store.memoryKeyValueStore["vamp/projects/1/clusters/2/release-agent-config"] = `{"applications":{"shop":2}}`
+	store.memoryKeyValueStore["vamp/projects/1/clusters/2/applications/2/service-configs/5"] = doctorTestServiceConfig
+	if issues, err := core.CheckProject(); err != nil || len(issues) != 0 {
+		t.Errorf("CheckProject() of clusters without applications and release plans = %v, %v, want no issues", issues, err)
+	}
+}
+
+func TestGetProjectIssueKeyTree(t *testing.T) {
+	store := memoryKeyValueStore{
+		"vamp/projects/1/clusters/9/applications/2/service-configs/5":     doctorTestServiceConfig,
+		"vamp/projects/1/clusters/9/applications/2/release-plans/5/1.0.0": `{}`,
+	}
+	core := newMemoryCore(store, nil)
+
+	keyTree, err := core.GetProjectIssueKeyTree(models.ProjectIssue{
+		Kind:    models.ClusterWithoutConfigIssue,
+		Key:     "vamp/projects/1/clusters/9",
+		Fixable: true,
+	})
+	if err != nil {
+		t.Fatalf("GetProjectIssueKeyTree() error = %v", err)
+	}
+	wantKeys := []string{"applications/2/release-plans/5/1.0.0", "applications/2/service-configs/5"}
+	if keyTree.Root != "vamp/projects/1/clusters/9" || !reflect.DeepEqual(keyTree.Keys, wantKeys) {
+		t.Errorf("GetProjectIssueKeyTree() = %v, want keys %v of the cluster", keyTree, wantKeys)
+	}
+
+	keyTree, err = core.GetProjectIssueKeyTree(models.ProjectIssue{
+		Kind:    models.OrphanedServiceConfigIssue,
+		Key:     "vamp/projects/1/clusters/9/applications/2/service-configs/5",
+		Fixable: true,
+	})
+	if err != nil || keyTree.Root != "vamp/projects/1/clusters/9/applications/2/service-configs" || !reflect.DeepEqual(keyTree.Keys, []string{"5"}) {
+		t.Errorf("GetProjectIssueKeyTree() of a single key = %v, %v", keyTree, err)
+	}
+}
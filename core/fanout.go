@@ -0,0 +1,35 @@
+package core
+
+import "sync"
+
+// maxConcurrentRequests - maximum number of concurrent requests to key value store
+const maxConcurrentRequests = 16
+
+// fanOut - runs functions concurrently with limited parallelism
+// functions can start other functions, Wait returns when all of them finish
+type fanOut struct {
+	waitGroup sync.WaitGroup
+	semaphore chan struct{}
+}
+
+func newFanOut(parallelism int) *fanOut {
+	return &fanOut{
+		semaphore: make(chan struct{}, parallelism),
+	}
+}
+
+// Go - runs function in a new goroutine as soon as there is a free slot
+func (f *fanOut) Go(function func()) {
+	f.waitGroup.Add(1)
+	go func() {
+		defer f.waitGroup.Done()
+		f.semaphore <- struct{}{}
+		defer func() { <-f.semaphore }()
+		function()
+	}()
+}
+
+// Wait - waits for all functions to finish
+func (f *fanOut) Wait() {
+	f.waitGroup.Wait()
+}
@@ -0,0 +1,40 @@
+package core
+
+import (
+	"sync/atomic"
+	"testing"
+)
+
+func TestFanOutRunsNestedFunctionsWithLimitedParallelism(t *testing.T) {
+	group := newFanOut(3)
+	var running, maxRunning, finished int32
+
+	run := func() {
+		current := atomic.AddInt32(&running, 1)
+		for {
+			max := atomic.LoadInt32(&maxRunning)
+			if current <= max || atomic.CompareAndSwapInt32(&maxRunning, max, current) {
+				break
+			}
+		}
+		atomic.AddInt32(&running, -1)
+		atomic.AddInt32(&finished, 1)
+	}
+
+	for i := 0; i < 10; i++ {
+		group.Go(func() {
+			run()
+			for j := 0; j < 10; j++ {
+				group.Go(run)
+			}
+		})
+	}
+	group.Wait()
+
+	if finished != 110 {
+		t.Errorf("finished functions = %d, want %d", finished, 110)
+	}
+	if maxRunning > 3 {
+		t.Errorf("max running functions = %d, want at most %d", maxRunning, 3)
+	}
+}
@@ -0,0 +1,151 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/magneticio/forklift/models"
+)
+
+const maxHookResponseBodyLength = 1024
+
+// TestPolicyHooks - sends onSuccess or onFailure hook requests of the policy with a sample payload
+// if target is not empty requests are sent to it instead of the hosts defined in the policy
+func (c *Core) TestPolicyHooks(policyID uint64, event string, target string, timeout time.Duration) ([]models.HookTestResult, error) {
+	policyText, err := c.GetPolicyString(policyID)
+	if err != nil {
+		return nil, err
+	}
+	policy, err := models.ParsePolicyDocument(policyText)
+	if err != nil {
+		return nil, err
+	}
+
+	var hooks []models.PolicyHook
+	switch event {
+	case models.HookEventSuccess:
+		hooks = policy.OnSuccess
+	case models.HookEventFailure:
+		hooks = policy.OnFailure
+	default:
+		return nil, fmt.Errorf("unknown event '%s', expected %s or %s", event, models.HookEventSuccess, models.HookEventFailure)
+	}
+	if len(hooks) == 0 {
+		return nil, fmt.Errorf("policy '%d' has no %s hooks", policyID, event)
+	}
+
+	payload, err := json.Marshal(models.NewHookTestPayload(policyID, event))
+	if err != nil {
+		return nil, fmt.Errorf("cannot serialize hook payload: %v", err)
+	}
+
+	client := &http.Client{Timeout: timeout}
+	return sendPolicyHooks(client, hooks, event, payload, target, c.resolveSecretReferences), nil
+}
+
+// resolveSecretReferences - replaces secret references in text with secret values
+func (c *Core) resolveSecretReferences(text string) (string, error) {
+	for _, reference := range models.FindTextSecretReferences(text) {
+		secretName, err := models.GetSecretReferenceName(reference)
+		if err != nil {
+			return "", err
+		}
+		secretValue, err := c.kvClient.Get(c.getSecretKey(secretName))
+		if err != nil {
+			return "", fmt.Errorf("cannot get secret '%s': %v", reference, err)
+		}
+		text = strings.Replace(text, models.FormatSecretReference(reference), secretValue, -1)
+	}
+	return text, nil
+}
+
+func sendPolicyHooks(client *http.Client, hooks []models.PolicyHook, event string, payload []byte, target string, resolve func(string) (string, error)) []models.HookTestResult {
+	results := make([]models.HookTestResult, len(hooks))
+	for i, hook := range hooks {
+		result := models.HookTestResult{
+			Event:  event,
+			Method: hook.Value.HTTPRequest,
+			URL:    hook.Value.URL,
+		}
+		if err := sendPolicyHook(client, hook, payload, target, resolve, &result); err != nil {
+			result.Error = err.Error()
+		}
+		results[i] = result
+	}
+	return results
+}
+
+func sendPolicyHook(client *http.Client, hook models.PolicyHook, payload []byte, target string, resolve func(string) (string, error), result *models.HookTestResult) error {
+	if target != "" {
+		targetURL, err := redirectHookURL(hook.Value.URL, target)
+		if err != nil {
+			return err
+		}
+		result.URL = targetURL
+	}
+
+	request, err := http.NewRequest(hook.Value.HTTPRequest, result.URL, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("cannot create request: %v", err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+	for _, header := range hook.Value.Headers {
+		separator := strings.Index(header, ":")
+		if separator < 0 {
+			return fmt.Errorf("invalid header '%s', expected <name>: <value>", strings.TrimSpace(header))
+		}
+		value, err := resolve(strings.TrimSpace(header[separator+1:]))
+		if err != nil {
+			return err
+		}
+		request.Header.Set(strings.TrimSpace(header[:separator]), value)
+	}
+
+	start := time.Now()
+	response, err := client.Do(request)
+	result.Latency = time.Since(start)
+	if err != nil {
+		return fmt.Errorf("request failed: %v", err)
+	}
+	defer response.Body.Close()
+
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("cannot read response: %v", err)
+	}
+	if len(body) > maxHookResponseBodyLength {
+		body = append(body[:maxHookResponseBodyLength], []byte("...")...)
+	}
+	result.StatusCode = response.StatusCode
+	result.Status = response.Status
+	result.Body = string(body)
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf("unexpected status %s", response.Status)
+	}
+	return nil
+}
+
+// redirectHookURL - replaces scheme and host of hook url with target keeping path and query
+func redirectHookURL(hookURL string, target string) (string, error) {
+	parsedHookURL, err := url.Parse(hookURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid hook url '%s': %v", hookURL, err)
+	}
+	parsedTarget, err := url.Parse(target)
+	if err != nil || parsedTarget.Scheme == "" || parsedTarget.Host == "" {
+		return "", fmt.Errorf("invalid target '%s', expected url like http://localhost:8080", target)
+	}
+	parsedHookURL.Scheme = parsedTarget.Scheme
+	parsedHookURL.Host = parsedTarget.Host
+	parsedHookURL.User = parsedTarget.User
+	if parsedTarget.Path != "" && parsedTarget.Path != "/" {
+		parsedHookURL.Path = strings.TrimSuffix(parsedTarget.Path, "/") + parsedHookURL.Path
+	}
+	return parsedHookURL.String(), nil
+}
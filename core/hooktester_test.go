@@ -0,0 +1,66 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/magneticio/forklift/models"
+)
+
+func TestSendPolicyHooksToTarget(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		var payload models.HookTestPayload
+		if err := json.Unmarshal(body, &payload); err != nil || !payload.Test || payload.Event != models.HookEventSuccess {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if r.URL.Path == "/broken" {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprint(w, "broken")
+			return
+		}
+		fmt.Fprintf(w, "%s %s %s", r.Method, r.URL.Path, r.Header.Get("Authorization"))
+	}))
+	defer server.Close()
+
+	hooks := []models.PolicyHook{
+		{Type: "http", Value: models.PolicyHookValue{URL: "https://hooks.example.com/notify?x=1", HTTPRequest: "POST", Headers: []string{"authorization: Bearer ${secret:project/hooks/slack}"}}},
+		{Type: "http", Value: models.PolicyHookValue{URL: "https://hooks.example.com/broken", HTTPRequest: "PUT"}},
+	}
+	resolve := func(text string) (string, error) {
+		return strings.Replace(text, "${secret:project/hooks/slack}", "token", -1), nil
+	}
+	payload, _ := json.Marshal(models.NewHookTestPayload(456, models.HookEventSuccess))
+
+	results := sendPolicyHooks(&http.Client{Timeout: time.Second}, hooks, models.HookEventSuccess, payload, server.URL, resolve)
+
+	if len(results) != 2 {
+		t.Fatalf("sendPolicyHooks() returned %d results, want 2", len(results))
+	}
+	if results[0].URL != server.URL+"/notify?x=1" || results[0].StatusCode != http.StatusOK || results[0].Error != "" {
+		t.Errorf("sendPolicyHooks() first result = %+v", results[0])
+	}
+	if results[0].Body != "POST /notify Bearer token" {
+		t.Errorf("sendPolicyHooks() first body = %q, want %q", results[0].Body, "POST /notify Bearer token")
+	}
+	if results[1].StatusCode != http.StatusInternalServerError || results[1].Body != "broken" || results[1].Error == "" {
+		t.Errorf("sendPolicyHooks() second result = %+v", results[1])
+	}
+}
+
+func TestRedirectHookURL(t *testing.T) {
+	got, err := redirectHookURL("https://hooks.example.com/a/b?c=d", "http://localhost:8080/base/")
+	if err != nil || got != "http://localhost:8080/base/a/b?c=d" {
+		t.Errorf("redirectHookURL() = %v, %v", got, err)
+	}
+	if _, err := redirectHookURL("https://hooks.example.com/a", "localhost"); err == nil {
+		t.Errorf("redirectHookURL() should fail for target without scheme")
+	}
+}
@@ -0,0 +1,57 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/magneticio/forklift/models"
+)
+
+// FindIngressConflicts - finds ingress domain and path pairs claimed by more than one service of the cluster
+func (c *Core) FindIngressConflicts() ([]models.IngressConflict, error) {
+	if c.clusterID == nil {
+		return nil, fmt.Errorf("cluster id must be provided")
+	}
+	entries, err := c.listServiceConfigs([]uint64{*c.clusterID})
+	if err != nil {
+		return nil, fmt.Errorf("cannot check ingress conflicts: %v", err)
+	}
+	claims := make([]models.IngressClaim, 0)
+	for _, entry := range entries {
+		claims = append(claims, models.GetIngressClaims(entry.applicationID, entry.serviceID, *entry.serviceConfig)...)
+	}
+	return models.FindIngressConflicts(claims), nil
+}
+
+// checkIngressConflicts - checks that ingress rules of service config are not claimed by other services of the cluster
+// the stored config of the same service is replaced by the new one
+func (c *Core) checkIngressConflicts(clusterID uint64, serviceConfig models.ServiceConfig) error {
+	if len(serviceConfig.IngressRules) == 0 {
+		return nil
+	}
+	exists, err := c.kvClient.Exists(c.getReleaseAgentConfigKey(clusterID))
+	if err != nil {
+		return fmt.Errorf("cannot find Release Agent config: %v", err)
+	}
+	if !exists {
+		return nil
+	}
+	entries, err := c.listServiceConfigs([]uint64{clusterID})
+	if err != nil {
+		return fmt.Errorf("cannot check ingress conflicts: %v", err)
+	}
+
+	applicationID, serviceID := *serviceConfig.ApplicationID, *serviceConfig.ServiceID
+	claims := models.GetIngressClaims(applicationID, serviceID, serviceConfig)
+	for _, entry := range entries {
+		if entry.applicationID == applicationID && entry.serviceID == serviceID {
+			continue
+		}
+		claims = append(claims, models.GetIngressClaims(entry.applicationID, entry.serviceID, *entry.serviceConfig)...)
+	}
+	for _, conflict := range models.FindIngressConflicts(claims) {
+		if conflict.Involves(applicationID, serviceID) {
+			return fmt.Errorf("%v, use --allow-shared to put it anyway", conflict)
+		}
+	}
+	return nil
+}
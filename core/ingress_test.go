@@ -0,0 +1,40 @@
+package core
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/magneticio/forklift/models"
+)
+
+func TestIngressConflictsFailOnScanErrors(t *testing.T) {
+	store := memoryKeyValueStore{
+		"vamp/projects/1/clusters/1/release-agent-config":             `{"applications":{"shop":2}}`,
+		"vamp/projects/1/clusters/1/applications/2/service-configs/5": `{"application_id":2,"service_id":5,"ingress_rules":[{"domain":"test.local","path":"/"}]}`,
+		"vamp/projects/1/clusters/1/applications/3/service-configs/6": `{"application_id":3,"service_id":6}`,
+	}
+	clusterID := uint64(1)
+	applicationID, serviceID := uint64(3), uint64(6)
+	serviceConfig := models.ServiceConfig{
+		ApplicationID: &applicationID,
+		ServiceID:     &serviceID,
+		IngressRules:  []*models.ServiceConfigIngressRule{{Domain: "test.local", Path: "/"}},
+	}
+
+	core := newMemoryCore(store, &clusterID)
+	if err := core.checkIngressConflicts(clusterID, serviceConfig); err == nil || !strings.Contains(err.Error(), "is claimed by") {
+		t.Errorf("checkIngressConflicts() error = %v, want conflict with service '5'", err)
+	}
+
+	failingCore := &Core{
+		kvClient:    failingListKeyValueStore{memoryKeyValueStore: store, failingDirectory: "vamp/projects/1/clusters/1/applications/2/service-configs"},
+		projectPath: "vamp/projects/1",
+		clusterID:   &clusterID,
+	}
+	if err := failingCore.checkIngressConflicts(clusterID, serviceConfig); err == nil || !strings.HasPrefix(err.Error(), "cannot check ingress conflicts") {
+		t.Errorf("checkIngressConflicts() error = %v, want scan error", err)
+	}
+	if conflicts, err := failingCore.FindIngressConflicts(); err == nil {
+		t.Errorf("FindIngressConflicts() = %v, want scan error", conflicts)
+	}
+}
@@ -0,0 +1,45 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGetKeyTrees(t *testing.T) {
+	store := memoryKeyValueStore{
+		"vamp/projects/1/clusters/1/release-agent-config":                 `{"applications":{"shop":2,"cart":3}}`,
+		"vamp/projects/1/clusters/1/applications/2/service-configs/5":     `{}`,
+		"vamp/projects/1/clusters/1/applications/2/release-plans/5/1.0.0": `{}`,
+	}
+	clusterID := uint64(1)
+	core := newMemoryCore(store, &clusterID)
+
+	clusterTree, err := core.GetClusterKeyTree(1)
+	if err != nil {
+		t.Fatalf("GetClusterKeyTree() error = %v", err)
+	}
+	wantKeys := []string{"applications/2/release-plans/5/1.0.0", "applications/2/service-configs/5", "release-agent-config"}
+	if !reflect.DeepEqual(clusterTree.Keys, wantKeys) {
+		t.Errorf("GetClusterKeyTree() keys = %v, want %v", clusterTree.Keys, wantKeys)
+	}
+
+	applicationTree, err := core.GetApplicationKeyTree(3)
+	if err != nil || len(applicationTree.Keys) != 0 {
+		t.Errorf("GetApplicationKeyTree() of application without keys = %v, %v, want no keys", applicationTree, err)
+	}
+	if _, err := core.GetApplicationKeyTree(4); err == nil || err.Error() != "application '4' not found" {
+		t.Errorf("GetApplicationKeyTree() of missing application error = %v, want application '4' not found", err)
+	}
+
+	failingCore := &Core{
+		kvClient:    failingListKeyValueStore{memoryKeyValueStore: store, failingDirectory: "vamp/projects/1/clusters/1/applications/2/release-plans"},
+		projectPath: "vamp/projects/1",
+		clusterID:   &clusterID,
+	}
+	if keyTree, err := failingCore.GetClusterKeyTree(1); err == nil {
+		t.Errorf("GetClusterKeyTree() = %v, want error when a directory cannot be listed", keyTree)
+	}
+	if keyTree, err := failingCore.GetApplicationKeyTree(2); err == nil {
+		t.Errorf("GetApplicationKeyTree() = %v, want error when a directory cannot be listed", keyTree)
+	}
+}
@@ -0,0 +1,53 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+)
+
+// failingListKeyValueStore - memory key value store which fails to list one directory
+type failingListKeyValueStore struct {
+	memoryKeyValueStore
+	failingDirectory string
+}
+
+func (f failingListKeyValueStore) List(directory string) ([]string, error) {
+	if strings.TrimSuffix(directory, "/") == f.failingDirectory {
+		return nil, fmt.Errorf("connection reset")
+	}
+	return f.memoryKeyValueStore.List(directory)
+}
+
+// failingPutKeyValueStore - memory key value store which fails to put one key
+type failingPutKeyValueStore struct {
+	memoryKeyValueStore
+	failingKey string
+}
+
+func (f failingPutKeyValueStore) Put(key string, value string) error {
+	if key == f.failingKey {
+		return fmt.Errorf("permission denied")
+	}
+	return f.memoryKeyValueStore.Put(key, value)
+}
+
+// strictListKeyValueStore - memory key value store which fails to list directories without keys like vault does
+type strictListKeyValueStore struct {
+	memoryKeyValueStore
+}
+
+func (s strictListKeyValueStore) List(directory string) ([]string, error) {
+	entries, err := s.memoryKeyValueStore.List(directory)
+	if err == nil && len(entries) == 0 {
+		return nil, fmt.Errorf("no directory '%s'", directory)
+	}
+	return entries, err
+}
+
+func newMemoryCore(store memoryKeyValueStore, clusterID *uint64) *Core {
+	return &Core{
+		kvClient:    store,
+		projectPath: "vamp/projects/1",
+		clusterID:   clusterID,
+	}
+}
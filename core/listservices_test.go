@@ -0,0 +1,23 @@
+package core
+
+import "testing"
+
+func TestListServicesReportsBrokenServices(t *testing.T) {
+	store := memoryKeyValueStore{
+		"vamp/projects/1/clusters/1/applications/2/service-configs/5": `{"application_id":2,"service_id":5,"k8s_namespace":"shop"}`,
+		"vamp/projects/1/clusters/1/applications/2/service-configs/6": `{"service_id":`,
+	}
+	clusterID := uint64(1)
+	core := newMemoryCore(store, &clusterID)
+
+	services, err := core.ListServices(2)
+	if err != nil {
+		t.Fatalf("ListServices() error = %v", err)
+	}
+	if len(services) != 2 || services[0].Namespace != "shop" || services[0].Error != "" {
+		t.Fatalf("ListServices() = %v, want readable service 5 and broken service 6", services)
+	}
+	if services[1].ID != 6 || services[1].Error == "" {
+		t.Errorf("ListServices() broken service = %+v, want error", services[1])
+	}
+}
@@ -0,0 +1,55 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// memoryKeyValueStore - key value store keeping values in memory, used to convert policies without storing them,
+// directories are listed by name without trailing slash like by the key value store client
+type memoryKeyValueStore map[string]string
+
+func (m memoryKeyValueStore) Get(key string) (string, error) {
+	value, exists := m[key]
+	if !exists {
+		return "", fmt.Errorf("key '%s' not found", key)
+	}
+	return value, nil
+}
+
+func (m memoryKeyValueStore) Exists(key string) (bool, error) {
+	_, exists := m[key]
+	return exists, nil
+}
+
+func (m memoryKeyValueStore) Put(key string, value string) error {
+	m[key] = value
+	return nil
+}
+
+func (m memoryKeyValueStore) Delete(key string) error {
+	delete(m, key)
+	return nil
+}
+
+func (m memoryKeyValueStore) List(directory string) ([]string, error) {
+	prefix := strings.TrimSuffix(directory, "/") + "/"
+	seen := make(map[string]bool)
+	entries := make([]string, 0)
+	for key := range m {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		entry := strings.TrimPrefix(key, prefix)
+		if separator := strings.Index(entry, "/"); separator >= 0 {
+			entry = entry[:separator]
+		}
+		if !seen[entry] {
+			seen[entry] = true
+			entries = append(entries, entry)
+		}
+	}
+	sort.Strings(entries)
+	return entries, nil
+}
@@ -0,0 +1,188 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+
+	"github.com/magneticio/forklift/logging"
+	"github.com/magneticio/forklift/models"
+)
+
+// GetPolicyIDByName - gets id of the policy with provided name
+func (c *Core) GetPolicyIDByName(policyName string) (uint64, error) {
+	policyNames, err := c.getPolicyNames()
+	if err != nil {
+		return 0, err
+	}
+	policyID, exists := policyNames[policyName]
+	if !exists {
+		return 0, fmt.Errorf("policy with name '%s' does not exist", policyName)
+	}
+	return policyID, nil
+}
+
+// GetOrAllocatePolicyID - gets id of the policy with provided name
+// or allocates a new id if there is no such policy yet
+func (c *Core) GetOrAllocatePolicyID(policyName string) (uint64, error) {
+	policyNames, err := c.getPolicyNames()
+	if err != nil {
+		return 0, err
+	}
+	if policyID, exists := policyNames[policyName]; exists {
+		return policyID, nil
+	}
+	maxPolicyID := uint64(0)
+	for _, policyID := range policyNames {
+		if policyID > maxPolicyID {
+			maxPolicyID = policyID
+		}
+	}
+	policyTypes, err := c.getPolicyTypes()
+	if err != nil {
+		return 0, err
+	}
+	for policyID := range policyTypes {
+		if policyID > maxPolicyID {
+			maxPolicyID = policyID
+		}
+	}
+	return maxPolicyID + 1, nil
+}
+
+// checkPolicyName - checks that name is valid and not used by another policy
+func (c *Core) checkPolicyName(policyID uint64, policyName string) error {
+	if err := models.ValidatePolicyName(policyName); err != nil {
+		return err
+	}
+	policyNames, err := c.getPolicyNames()
+	if err != nil {
+		return err
+	}
+	if existingPolicyID, exists := policyNames[policyName]; exists && existingPolicyID != policyID {
+		return fmt.Errorf("policy name '%s' is already used by policy '%d'", policyName, existingPolicyID)
+	}
+	return nil
+}
+
+// setPolicyName - assigns unique name to the policy replacing its previous name
+func (c *Core) setPolicyName(policyID uint64, policyName string) error {
+	if err := c.checkPolicyName(policyID, policyName); err != nil {
+		return err
+	}
+	policyNames, err := c.getPolicyNames()
+	if err != nil {
+		return err
+	}
+	if existingPolicyID, exists := policyNames[policyName]; exists && existingPolicyID == policyID {
+		return nil
+	}
+	for name, namedPolicyID := range policyNames {
+		if namedPolicyID == policyID {
+			delete(policyNames, name)
+		}
+	}
+	policyNames[policyName] = policyID
+	return c.savePolicyNames(policyNames)
+}
+
+// removePolicyName - removes name of the policy if it has one
+func (c *Core) removePolicyName(policyID uint64) error {
+	policyNames, err := c.getPolicyNames()
+	if err != nil {
+		return err
+	}
+	removed := false
+	for name, namedPolicyID := range policyNames {
+		if namedPolicyID == policyID {
+			delete(policyNames, name)
+			removed = true
+		}
+	}
+	if !removed {
+		return nil
+	}
+	return c.savePolicyNames(policyNames)
+}
+
+// resolveServiceConfigPolicyNames - replaces policy names in service config with policy ids
+// returns true if any name has been resolved
+func (c *Core) resolveServiceConfigPolicyNames(serviceConfig *models.ServiceConfig) (bool, error) {
+	slots := []struct {
+		name       string
+		policyName *string
+		policyID   **uint64
+	}{
+		{"default", &serviceConfig.DefaultPolicyName, &serviceConfig.DefaultPolicyID},
+		{"patch", &serviceConfig.PatchPolicyName, &serviceConfig.PatchPolicyID},
+		{"minor", &serviceConfig.MinorPolicyName, &serviceConfig.MinorPolicyID},
+		{"major", &serviceConfig.MajorPolicyName, &serviceConfig.MajorPolicyID},
+	}
+	var policyNames map[string]uint64
+	resolved := false
+	for _, slot := range slots {
+		if *slot.policyName == "" {
+			continue
+		}
+		if policyNames == nil {
+			var err error
+			policyNames, err = c.getPolicyNames()
+			if err != nil {
+				return false, err
+			}
+		}
+		policyID, exists := policyNames[*slot.policyName]
+		if !exists {
+			return false, fmt.Errorf("policy '%s' referenced by %s_policy_name does not exist", *slot.policyName, slot.name)
+		}
+		*slot.policyID = &policyID
+		*slot.policyName = ""
+		resolved = true
+	}
+	return resolved, nil
+}
+
+func (c *Core) getPolicyNamesKey() string {
+	return path.Join(c.projectPath, "policy-names")
+}
+
+func (c *Core) getPolicyNames() (map[string]uint64, error) {
+	policyNamesKey := c.getPolicyNamesKey()
+	exists, err := c.kvClient.Exists(policyNamesKey)
+	if err != nil {
+		return nil, fmt.Errorf("cannot check if policy names exist: %v", err)
+	}
+	policyNames := make(map[string]uint64)
+	if !exists {
+		return policyNames, nil
+	}
+	policyNamesText, err := c.kvClient.Get(policyNamesKey)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get policy names: %v", err)
+	}
+	if err := json.Unmarshal([]byte(policyNamesText), &policyNames); err != nil {
+		return nil, fmt.Errorf("cannot deserialize policy names: %v", err)
+	}
+	return policyNames, nil
+}
+
+func (c *Core) savePolicyNames(policyNames map[string]uint64) error {
+	policyNamesBytes, err := json.Marshal(policyNames)
+	if err != nil {
+		return fmt.Errorf("cannot serialize policy names: %v", err)
+	}
+	return c.kvClient.Put(c.getPolicyNamesKey(), string(policyNamesBytes))
+}
+
+func (c *Core) getPolicyNamesByID() map[uint64]string {
+	policyNames, err := c.getPolicyNames()
+	if err != nil {
+		logging.Error("cannot get policy names: %v", err)
+		return make(map[uint64]string)
+	}
+	policyNamesByID := make(map[uint64]string, len(policyNames))
+	for name, policyID := range policyNames {
+		policyNamesByID[policyID] = name
+	}
+	return policyNamesByID
+}
@@ -0,0 +1,31 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDeletePolicyKeepsPolicyWhenNameCannotBeRemoved(t *testing.T) {
+	store := memoryKeyValueStore{
+		"vamp/projects/1/policies/1":   `{"name":"canary"}`,
+		"vamp/projects/1/policy-names": `{"canary":1}`,
+	}
+	core := &Core{
+		kvClient:    failingPutKeyValueStore{memoryKeyValueStore: store, failingKey: "vamp/projects/1/policy-names"},
+		projectPath: "vamp/projects/1",
+	}
+	want := memoryKeyValueStore{
+		"vamp/projects/1/policies/1":   store["vamp/projects/1/policies/1"],
+		"vamp/projects/1/policy-names": store["vamp/projects/1/policy-names"],
+	}
+
+	if err := core.DeletePolicy(1, true); err == nil {
+		t.Fatalf("DeletePolicy() should fail when policy name cannot be removed")
+	}
+	if !reflect.DeepEqual(store, want) {
+		t.Errorf("store = %v, want policy and its name kept %v", store, want)
+	}
+	if policyID, err := core.GetPolicyIDByName("canary"); err != nil || policyID != 1 {
+		t.Errorf("GetPolicyIDByName() = %v, %v, want 1", policyID, err)
+	}
+}
@@ -0,0 +1,239 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/magneticio/forklift/models"
+	policies "github.com/magneticio/vamp-policies"
+	"github.com/magneticio/vamp-policies/policy/interface/api"
+)
+
+type serviceConfigEntry struct {
+	clusterID     uint64
+	applicationID uint64
+	serviceID     uint64
+	serviceConfig *models.ServiceConfig
+}
+
+// FindPolicyReferences - finds service configs referencing policy in all clusters and applications
+func (c *Core) FindPolicyReferences(policyID uint64) ([]models.PolicyReference, error) {
+	entries, err := c.listAllServiceConfigs()
+	if err != nil {
+		return nil, err
+	}
+	references := make([]models.PolicyReference, 0)
+	for _, entry := range entries {
+		for _, reference := range entry.serviceConfig.PolicyReferences() {
+			if reference.PolicyID == policyID {
+				references = append(references, models.PolicyReference{
+					ClusterID:     entry.clusterID,
+					ApplicationID: entry.applicationID,
+					ServiceID:     entry.serviceID,
+					Slot:          reference.Slot,
+				})
+			}
+		}
+	}
+	return references, nil
+}
+
+// listAllServiceConfigs - lists service configs of all applications in all clusters
+func (c *Core) listAllServiceConfigs() ([]serviceConfigEntry, error) {
+	exists, err := c.projectDirectoryExists("clusters")
+	if err != nil {
+		return nil, fmt.Errorf("cannot list clusters: %v", err)
+	}
+	if !exists {
+		return make([]serviceConfigEntry, 0), nil
+	}
+	clusterIDs, err := c.listIDs(path.Join(c.projectPath, "clusters"))
+	if err != nil {
+		return nil, fmt.Errorf("cannot list clusters: %v", err)
+	}
+	return c.listServiceConfigs(clusterIDs)
+}
+
+// listServiceConfigs - lists service configs of all applications stored in given clusters
+// including applications which are not mapped in Release Agent configs,
+// clusters, applications and services are fetched concurrently and the first failure fails the whole listing
+func (c *Core) listServiceConfigs(clusterIDs []uint64) ([]serviceConfigEntry, error) {
+	var mutex sync.Mutex
+	var firstErr error
+	reportError := func(err error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	entries := make([]serviceConfigEntry, 0)
+	group := newFanOut(maxConcurrentRequests)
+
+	for _, clusterID := range clusterIDs {
+		clusterID := clusterID
+		group.Go(func() {
+			clusterPath := c.getClusterPath(clusterID)
+			exists, err := c.directoryExists(clusterPath, "applications")
+			if err != nil {
+				reportError(fmt.Errorf("cannot list applications of cluster '%d': %v", clusterID, err))
+				return
+			}
+			if !exists {
+				return
+			}
+			applicationIDs, err := c.listIDs(path.Join(clusterPath, "applications"))
+			if err != nil {
+				reportError(fmt.Errorf("cannot list applications of cluster '%d': %v", clusterID, err))
+				return
+			}
+			for _, applicationID := range applicationIDs {
+				applicationID := applicationID
+				group.Go(func() {
+					exists, err := c.directoryExists(c.getApplicationPath(clusterID, applicationID), "service-configs")
+					if err != nil {
+						reportError(fmt.Errorf("cannot list services of application '%d' in cluster '%d': %v", applicationID, clusterID, err))
+						return
+					}
+					if !exists {
+						return
+					}
+					serviceIDs, err := c.listIDs(c.getServiceConfigsPath(clusterID, applicationID))
+					if err != nil {
+						reportError(fmt.Errorf("cannot list services of application '%d' in cluster '%d': %v", applicationID, clusterID, err))
+						return
+					}
+					for _, serviceID := range serviceIDs {
+						serviceID := serviceID
+						group.Go(func() {
+							serviceConfig, err := c.getServiceConfig(clusterID, applicationID, serviceID)
+							if err != nil {
+								reportError(fmt.Errorf("cannot get service '%d' of application '%d' in cluster '%d': %v", serviceID, applicationID, clusterID, err))
+								return
+							}
+							mutex.Lock()
+							entries = append(entries, serviceConfigEntry{
+								clusterID:     clusterID,
+								applicationID: applicationID,
+								serviceID:     serviceID,
+								serviceConfig: serviceConfig,
+							})
+							mutex.Unlock()
+						})
+					}
+				})
+			}
+		})
+	}
+	group.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].clusterID != entries[j].clusterID {
+			return entries[i].clusterID < entries[j].clusterID
+		}
+		if entries[i].applicationID != entries[j].applicationID {
+			return entries[i].applicationID < entries[j].applicationID
+		}
+		return entries[i].serviceID < entries[j].serviceID
+	})
+
+	return entries, nil
+}
+
+// FindUnusedPolicies - finds policies which are not referenced by any service config
+func (c *Core) FindUnusedPolicies() ([]models.PolicyView, error) {
+	policyViews, err := c.ListPolicies()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := c.listAllServiceConfigs()
+	if err != nil {
+		return nil, err
+	}
+	usedPolicyIDs := make(map[uint64]bool)
+	for _, entry := range entries {
+		for _, reference := range entry.serviceConfig.PolicyReferences() {
+			usedPolicyIDs[reference.PolicyID] = true
+		}
+	}
+	unusedPolicies := make([]models.PolicyView, 0)
+	for _, policyView := range policyViews {
+		if !usedPolicyIDs[policyView.ID] {
+			unusedPolicies = append(unusedPolicies, policyView)
+		}
+	}
+	sort.Slice(unusedPolicies, func(i, j int) bool {
+		return unusedPolicies[i].ID < unusedPolicies[j].ID
+	})
+	return unusedPolicies, nil
+}
+
+// checkServiceConfigPolicies - checks that policies referenced by service config exist
+// and are release policies, or validation policies in case of validation_policy_ids
+func (c *Core) checkServiceConfigPolicies(serviceConfig models.ServiceConfig) error {
+	references := serviceConfig.PolicyReferences()
+	if len(references) == 0 {
+		return nil
+	}
+	policyTypes, err := c.getPolicyTypes()
+	if err != nil {
+		return err
+	}
+	for _, reference := range references {
+		field := models.GetPolicySlotField(reference.Slot)
+		policyType, exists := policyTypes[reference.PolicyID]
+		if !exists {
+			return fmt.Errorf("policy '%d' referenced by %s does not exist", reference.PolicyID, field)
+		}
+		expectedType := api.ReleasePolicyType
+		if reference.Slot == models.ValidationPolicySlot {
+			expectedType = api.ValidationPolicyType
+		}
+		if policyType != expectedType {
+			return fmt.Errorf("policy '%d' referenced by %s is a %s policy, %s policy expected", reference.PolicyID, field, policyType, expectedType)
+		}
+	}
+	return nil
+}
+
+// getPolicyTypes - gets types of all policies by id, a project without policies directory has no policies
+func (c *Core) getPolicyTypes() (map[uint64]api.PolicyType, error) {
+	policyAPI := policies.NewPolicyAPI(c.kvClient, c.projectPath)
+	apiPolicyViews, err := policyAPI.FindAll()
+	if err != nil {
+		exists, existsErr := c.projectDirectoryExists("policies")
+		if existsErr == nil && !exists {
+			return make(map[uint64]api.PolicyType), nil
+		}
+		return nil, fmt.Errorf("cannot list policies: %v", err)
+	}
+	policyTypes := make(map[uint64]api.PolicyType, len(apiPolicyViews))
+	for _, apiPolicyView := range apiPolicyViews {
+		policyTypes[apiPolicyView.PolicyID] = apiPolicyView.PolicyType
+	}
+	return policyTypes, nil
+}
+
+func newPolicyReferencedError(policyID uint64, references []models.PolicyReference) error {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("policy '%d' is referenced by service configs:", policyID))
+	for _, reference := range references {
+		sb.WriteString(fmt.Sprintf(
+			"\ncluster '%d', application '%d', service '%d' (%s)",
+			reference.ClusterID,
+			reference.ApplicationID,
+			reference.ServiceID,
+			models.GetPolicySlotField(reference.Slot),
+		))
+	}
+	sb.WriteString("\nuse --force to delete it anyway")
+	return errors.New(sb.String())
+}
@@ -0,0 +1,34 @@
+package core
+
+import "testing"
+
+func TestListAllServiceConfigs(t *testing.T) {
+	store := memoryKeyValueStore{
+		"vamp/projects/1/clusters/1/release-agent-config":             `{"applications":{"shop":2}}`,
+		"vamp/projects/1/clusters/1/applications/2/service-configs/5": `{"application_id":2,"service_id":5}`,
+		"vamp/projects/1/clusters/1/applications/3/service-configs/6": `{"application_id":3,"service_id":6}`,
+		"vamp/projects/1/clusters/2/applications/4/release-plans/7/1": `{}`,
+	}
+	core := newMemoryCore(store, nil)
+
+	entries, err := core.listAllServiceConfigs()
+	if err != nil {
+		t.Fatalf("listAllServiceConfigs() error = %v", err)
+	}
+	if len(entries) != 2 || entries[0].applicationID != 2 || entries[1].applicationID != 3 || entries[1].serviceID != 6 {
+		t.Errorf("listAllServiceConfigs() = %v, want services of mapped and unmapped applications", entries)
+	}
+
+	failingCore := &Core{
+		kvClient:    failingListKeyValueStore{memoryKeyValueStore: store, failingDirectory: "vamp/projects/1/clusters/1/applications/3/service-configs"},
+		projectPath: "vamp/projects/1",
+	}
+	if entries, err := failingCore.listAllServiceConfigs(); err == nil {
+		t.Errorf("listAllServiceConfigs() = %v, want error when services cannot be listed", entries)
+	}
+
+	store["vamp/projects/1/clusters/1/applications/3/service-configs/6"] = `{"service_id":`
+	if entries, err := core.listAllServiceConfigs(); err == nil {
+		t.Errorf("listAllServiceConfigs() = %v, want error when a service config cannot be read", entries)
+	}
+}
@@ -0,0 +1,58 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/magneticio/forklift/models"
+)
+
+// ResolvePolicy - resolves policy used to release toVersion of the service after fromVersion
+// if fromVersion is empty the version of the latest existing release plan is used
+func (c *Core) ResolvePolicy(applicationID, serviceID uint64, fromVersion, toVersion string) (*models.PolicyResolution, error) {
+	if c.clusterID == nil {
+		return nil, fmt.Errorf("cluster id must be provided")
+	}
+	nextVersion, err := models.ParseServiceVersion(toVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	serviceConfig, err := c.getServiceConfig(*c.clusterID, applicationID, serviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	var previousVersion *models.ServiceVersion
+	if fromVersion != "" {
+		previousVersion, err = models.ParseServiceVersion(fromVersion)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		previousVersion, fromVersion, err = c.getLatestReleasePlanVersion(applicationID, serviceID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	bump, err := models.GetVersionBump(previousVersion, *nextVersion)
+	if err != nil {
+		return nil, err
+	}
+	selection, err := serviceConfig.SelectPolicy(bump)
+	if err != nil {
+		return nil, err
+	}
+	policyText, err := c.GetPolicyString(selection.PolicyID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot resolve policy '%d': %v", selection.PolicyID, err)
+	}
+
+	return &models.PolicyResolution{
+		FromVersion: fromVersion,
+		ToVersion:   toVersion,
+		Bump:        bump,
+		Policy:      *selection,
+		PolicyText:  policyText,
+	}, nil
+}
@@ -0,0 +1,23 @@
+package core
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestResolvePolicyFailsWhenReleasePlansCannotBeListed(t *testing.T) {
+	store := memoryKeyValueStore{
+		"vamp/projects/1/clusters/1/applications/2/service-configs/5":     `{"application_id":2,"service_id":5,"default_policy_id":1}`,
+		"vamp/projects/1/clusters/1/applications/2/release-plans/5/2.3.0": `{}`,
+	}
+	clusterID := uint64(1)
+	failingCore := &Core{
+		kvClient:    failingListKeyValueStore{memoryKeyValueStore: store, failingDirectory: "vamp/projects/1/clusters/1/applications/2/release-plans/5"},
+		projectPath: "vamp/projects/1",
+		clusterID:   &clusterID,
+	}
+
+	if resolution, err := failingCore.ResolvePolicy(2, 5, "", "2.4.0"); err == nil || !strings.HasPrefix(err.Error(), "cannot list release plans") {
+		t.Errorf("ResolvePolicy() = %v, %v, want listing error instead of initial version bump", resolution, err)
+	}
+}
@@ -0,0 +1,102 @@
+package core
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/magneticio/forklift/models"
+)
+
+// GetProjectKeyTree - gets all keys of the project with their resource types,
+// the tree of a project without any keys is empty
+func (c *Core) GetProjectKeyTree() (*models.KeyTree, error) {
+	exists, err := c.projectDirectoryExists("")
+	if err != nil {
+		return nil, fmt.Errorf("cannot list keys of the project: %v", err)
+	}
+	keys := make([]string, 0)
+	if exists {
+		if keys, err = c.listKeysRecursively(c.projectPath, ""); err != nil {
+			return nil, fmt.Errorf("cannot list keys of the project: %v", err)
+		}
+	}
+	keyTypes := make(map[string]string)
+	for _, key := range keys {
+		segments := strings.Split(key, "/")
+		for i := range segments {
+			nodePath := strings.Join(segments[:i+1], "/")
+			isKey := i == len(segments)-1
+			if keyType := models.GetProjectKeyType(nodePath, isKey); keyType != "" && (isKey || keyTypes[nodePath] == "") {
+				keyTypes[nodePath] = keyType
+			}
+		}
+	}
+	return &models.KeyTree{
+		Root:  c.projectPath,
+		Keys:  keys,
+		Types: keyTypes,
+	}, nil
+}
+
+// GetRawKey - gets value of a key relative to the project path without any validation
+func (c *Core) GetRawKey(relativeKey string) (string, error) {
+	key, err := c.getRawKeyPath(relativeKey)
+	if err != nil {
+		return "", err
+	}
+	exists, err := c.kvClient.Exists(key)
+	if err != nil {
+		return "", fmt.Errorf("cannot find key '%s': %v", key, err)
+	}
+	if !exists {
+		return "", fmt.Errorf("key '%s' does not exist", key)
+	}
+	return c.kvClient.Get(key)
+}
+
+// ListRawKeys - lists names of keys and directories in a directory relative to the project path
+func (c *Core) ListRawKeys(relativeDirectory string) ([]string, error) {
+	directory := c.projectPath
+	if strings.Trim(relativeDirectory, "/") != "" {
+		var err error
+		if directory, err = c.getRawKeyPath(relativeDirectory); err != nil {
+			return nil, err
+		}
+	}
+	return c.listEntries(directory)
+}
+
+// PutRawKey - puts value of a key relative to the project path without any validation
+func (c *Core) PutRawKey(relativeKey string, value string) error {
+	key, err := c.getRawKeyPath(relativeKey)
+	if err != nil {
+		return err
+	}
+	return c.kvClient.Put(key, value)
+}
+
+// DeleteRawKey - deletes a key relative to the project path without any checks of references
+func (c *Core) DeleteRawKey(relativeKey string) error {
+	key, err := c.getRawKeyPath(relativeKey)
+	if err != nil {
+		return err
+	}
+	exists, err := c.kvClient.Exists(key)
+	if err != nil {
+		return fmt.Errorf("cannot find key '%s': %v", key, err)
+	}
+	if !exists {
+		return fmt.Errorf("key '%s' does not exist", key)
+	}
+	return c.kvClient.Delete(key)
+}
+
+// getRawKeyPath - gets full path of a key relative to the project path, keys outside of the project are rejected
+func (c *Core) getRawKeyPath(relativeKey string) (string, error) {
+	key := path.Join(c.projectPath, relativeKey)
+	if !strings.HasPrefix(key, c.projectPath+"/") {
+		return "", fmt.Errorf("key '%s' is outside of the project", relativeKey)
+	}
+	return key, nil
+}
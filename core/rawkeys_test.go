@@ -0,0 +1,54 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRawKeysAreScopedToProject(t *testing.T) {
+	store := memoryKeyValueStore{
+		"vamp/projects/1/policies/1": `{}`,
+		"vamp/projects/2/policies/1": `{}`,
+	}
+	core := newMemoryCore(store, nil)
+
+	for _, key := range []string{"../2/policies/1", "/../../projects/2/policies/1", "", "/"} {
+		if _, err := core.GetRawKey(key); err == nil {
+			t.Errorf("GetRawKey(%q) should fail", key)
+		}
+		if err := core.PutRawKey(key, "{}"); err == nil {
+			t.Errorf("PutRawKey(%q) should fail", key)
+		}
+	}
+
+	if value, err := core.GetRawKey("/policies/1"); err != nil || value != `{}` {
+		t.Errorf("GetRawKey() = %v, %v, want {}", value, err)
+	}
+	if err := core.PutRawKey("secrets/hooks/slack", "token"); err != nil {
+		t.Fatalf("PutRawKey() error = %v", err)
+	}
+	if entries, err := core.ListRawKeys(""); err != nil || !reflect.DeepEqual(entries, []string{"policies", "secrets"}) {
+		t.Errorf("ListRawKeys() = %v, %v, want [policies secrets]", entries, err)
+	}
+	if err := core.DeleteRawKey("policies/1"); err != nil {
+		t.Fatalf("DeleteRawKey() error = %v", err)
+	}
+	if _, exists := store["vamp/projects/2/policies/1"]; !exists || len(store) != 2 {
+		t.Errorf("store = %v, want only policy of project 1 deleted", store)
+	}
+}
+
+func TestGetProjectKeyTreeOfEmptyProject(t *testing.T) {
+	store := strictListKeyValueStore{memoryKeyValueStore{
+		"vamp/projects/2/policies/1": `{}`,
+	}}
+	core := &Core{kvClient: store, projectPath: "vamp/projects/1"}
+
+	keyTree, err := core.GetProjectKeyTree()
+	if err != nil {
+		t.Fatalf("GetProjectKeyTree() error = %v", err)
+	}
+	if len(keyTree.Keys) != 0 || keyTree.String() != "vamp/projects/1\n" {
+		t.Errorf("GetProjectKeyTree() = %q, want empty tree", keyTree.String())
+	}
+}
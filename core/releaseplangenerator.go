@@ -0,0 +1,164 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"strconv"
+
+	"github.com/magneticio/forklift/models"
+)
+
+// GenerateReleasePlan - generates not started release plan for a new service version
+// release groups are copied from the latest existing release plan unless provided explicitly
+func (c *Core) GenerateReleasePlan(applicationID, serviceID uint64, serviceVersion, serviceName string, releaseGroups [][]string) (*models.GeneratedReleasePlan, error) {
+	if c.clusterID == nil {
+		return nil, fmt.Errorf("cluster id must be provided")
+	}
+	nextVersion, err := models.ParseServiceVersion(serviceVersion)
+	if err != nil {
+		return nil, err
+	}
+	serviceConfig, err := c.getServiceConfig(*c.clusterID, applicationID, serviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	previousVersion, previousVersionText, err := c.getLatestReleasePlanVersion(applicationID, serviceID)
+	if err != nil {
+		return nil, err
+	}
+	bump, err := models.GetVersionBump(previousVersion, *nextVersion)
+	if err != nil {
+		return nil, err
+	}
+	selection, err := serviceConfig.SelectPolicy(bump)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.checkServiceConfigPolicies(*serviceConfig); err != nil {
+		return nil, err
+	}
+
+	var previousReleasePlan *models.ReleasePlan
+	if previousVersion != nil {
+		previousReleasePlan, err = c.getReleasePlan(applicationID, serviceID, previousVersionText)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if serviceName == "" {
+		serviceName = getReleasePlanServiceName(serviceID, *serviceConfig, previousReleasePlan)
+	}
+
+	var groups []models.ReleasePlanReleaseGroup
+	switch {
+	case len(releaseGroups) > 0:
+		groups = newReleasePlanReleaseGroups(releaseGroups)
+	case previousReleasePlan != nil && len(previousReleasePlan.ReleaseGroups) > 0:
+		groups = resetReleasePlanReleaseGroups(previousReleasePlan.ReleaseGroups)
+	default:
+		return nil, fmt.Errorf("release groups must be provided as there is no previous release plan to copy them from")
+	}
+
+	return &models.GeneratedReleasePlan{
+		ReleasePlan: models.ReleasePlan{
+			Status: models.ReleasePlanStatusNotStarted,
+			Service: models.ReleasePlanService{
+				Name:    serviceName,
+				Version: serviceVersion,
+			},
+			ReleaseGroups: groups,
+		},
+		PreviousVersion: previousVersionText,
+		Bump:            bump,
+		Policy:          *selection,
+	}, nil
+}
+
+// getLatestReleasePlanVersion - gets the latest version with a release plan
+// nil is returned only if the service has no release plans directory, listing failures are returned
+func (c *Core) getLatestReleasePlanVersion(applicationID, serviceID uint64) (*models.ServiceVersion, string, error) {
+	releasePlansPath, err := c.getReleasePlansPath(applicationID, serviceID)
+	if err != nil {
+		return nil, "", err
+	}
+	exists, err := c.directoryExists(c.getApplicationPath(*c.clusterID, applicationID), path.Join("release-plans", strconv.FormatUint(serviceID, 10)))
+	if err != nil {
+		return nil, "", fmt.Errorf("cannot list release plans: %v", err)
+	}
+	if !exists {
+		return nil, "", nil
+	}
+	releasePlanKeys, err := c.listEntries(releasePlansPath)
+	if err != nil {
+		return nil, "", fmt.Errorf("cannot list release plans: %v", err)
+	}
+	latestVersion, latestVersionText := models.GetLatestServiceVersion(releasePlanKeys)
+	return latestVersion, latestVersionText, nil
+}
+
+func (c *Core) getReleasePlan(applicationID, serviceID uint64, serviceVersion string) (*models.ReleasePlan, error) {
+	releasePlanText, err := c.GetReleasePlanText(applicationID, serviceID, serviceVersion)
+	if err != nil {
+		return nil, err
+	}
+	var releasePlan models.ReleasePlan
+	if err := json.Unmarshal([]byte(releasePlanText), &releasePlan); err != nil {
+		return nil, fmt.Errorf("cannot deserialize release plan '%s': %v", serviceVersion, err)
+	}
+	return &releasePlan, nil
+}
+
+func getReleasePlanServiceName(serviceID uint64, serviceConfig models.ServiceConfig, previousReleasePlan *models.ReleasePlan) string {
+	if previousReleasePlan != nil && previousReleasePlan.Service.Name != "" {
+		return previousReleasePlan.Service.Name
+	}
+	if appLabel, ok := serviceConfig.K8sLabels["app"]; ok && appLabel != "" {
+		return appLabel
+	}
+	return strconv.FormatUint(serviceID, 10)
+}
+
+func newReleasePlanReleaseGroups(releaseGroups [][]string) []models.ReleasePlanReleaseGroup {
+	groups := make([]models.ReleasePlanReleaseGroup, len(releaseGroups))
+	for i, environmentIDs := range releaseGroups {
+		environments := make([]models.ReleasePlanEnvironment, len(environmentIDs))
+		for j, environmentID := range environmentIDs {
+			environments[j] = models.ReleasePlanEnvironment{
+				ID:     environmentID,
+				Name:   environmentID,
+				Status: models.ReleasePlanStatusNotStarted,
+			}
+		}
+		groups[i] = models.ReleasePlanReleaseGroup{
+			Group:        i + 1,
+			Name:         fmt.Sprintf("group-%d", i+1),
+			Status:       models.ReleasePlanStatusNotStarted,
+			Environments: environments,
+		}
+	}
+	return groups
+}
+
+func resetReleasePlanReleaseGroups(previousGroups []models.ReleasePlanReleaseGroup) []models.ReleasePlanReleaseGroup {
+	groups := make([]models.ReleasePlanReleaseGroup, len(previousGroups))
+	for i, previousGroup := range previousGroups {
+		environments := make([]models.ReleasePlanEnvironment, len(previousGroup.Environments))
+		for j, previousEnvironment := range previousGroup.Environments {
+			environments[j] = models.ReleasePlanEnvironment{
+				ID:     previousEnvironment.ID,
+				Name:   previousEnvironment.Name,
+				Status: models.ReleasePlanStatusNotStarted,
+			}
+		}
+		groups[i] = models.ReleasePlanReleaseGroup{
+			Group:        previousGroup.Group,
+			Name:         previousGroup.Name,
+			Status:       models.ReleasePlanStatusNotStarted,
+			Environments: environments,
+		}
+	}
+	return groups
+}
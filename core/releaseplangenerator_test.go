@@ -0,0 +1,32 @@
+package core
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGetLatestReleasePlanVersion(t *testing.T) {
+	store := memoryKeyValueStore{
+		"vamp/projects/1/clusters/1/applications/2/service-configs/5":     `{"application_id":2,"service_id":5,"default_policy_id":1}`,
+		"vamp/projects/1/clusters/1/applications/2/release-plans/5/2.3.0": `{}`,
+		"vamp/projects/1/clusters/1/applications/2/release-plans/5/2.2.9": `{}`,
+	}
+	clusterID := uint64(1)
+	core := newMemoryCore(store, &clusterID)
+
+	if _, version, err := core.getLatestReleasePlanVersion(2, 5); err != nil || version != "2.3.0" {
+		t.Errorf("getLatestReleasePlanVersion() = %v, %v, want 2.3.0", version, err)
+	}
+	if latest, version, err := core.getLatestReleasePlanVersion(2, 6); err != nil || latest != nil || version != "" {
+		t.Errorf("getLatestReleasePlanVersion() of service without release plans = %v, %v, want none", version, err)
+	}
+
+	failingCore := &Core{
+		kvClient:    failingListKeyValueStore{memoryKeyValueStore: store, failingDirectory: "vamp/projects/1/clusters/1/applications/2/release-plans"},
+		projectPath: "vamp/projects/1",
+		clusterID:   &clusterID,
+	}
+	if _, err := failingCore.GenerateReleasePlan(2, 5, "2.4.0", "", [][]string{{"dev"}}); err == nil || !strings.HasPrefix(err.Error(), "cannot list release plans") {
+		t.Errorf("GenerateReleasePlan() error = %v, want listing error instead of initial version bump", err)
+	}
+}
@@ -0,0 +1,114 @@
+package core
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/magneticio/forklift/models"
+)
+
+// BuildSearchIndex - collects searchable fields of all clusters, applications, services and policies of the project
+// applications, service configs and policies are fetched concurrently
+func (c *Core) BuildSearchIndex() (*models.SearchIndex, error) {
+	clusters, err := c.ListClusters()
+	if err != nil {
+		return nil, err
+	}
+
+	var mutex sync.Mutex
+	var firstErr error
+	documents := make([]models.SearchDocument, 0)
+	addDocument := func(document models.SearchDocument) {
+		mutex.Lock()
+		documents = append(documents, document)
+		mutex.Unlock()
+	}
+	reportError := func(err error) {
+		mutex.Lock()
+		if firstErr == nil {
+			firstErr = err
+		}
+		mutex.Unlock()
+	}
+	group := newFanOut(maxConcurrentRequests)
+
+	for _, cluster := range clusters {
+		cluster := cluster
+		addDocument(models.SearchDocument{
+			Kind:      models.SearchKindCluster,
+			ClusterID: cluster.ID,
+			Key:       c.getReleaseAgentConfigKey(cluster.ID),
+			Fields: map[string][]string{
+				models.SearchFieldNatsChannel: {cluster.NatsChannel, cluster.OptimiserNatsChannel},
+			},
+		})
+		group.Go(func() {
+			applications, err := c.listApplications(cluster.ID)
+			if err != nil {
+				reportError(fmt.Errorf("cannot list applications of cluster '%d': %v", cluster.ID, err))
+				return
+			}
+			for _, application := range applications {
+				addDocument(models.SearchDocument{
+					Kind:          models.SearchKindApplication,
+					ClusterID:     cluster.ID,
+					ApplicationID: application.ID,
+					Key:           c.getApplicationPath(cluster.ID, application.ID),
+					Fields: map[string][]string{
+						models.SearchFieldNamespace: application.Namespaces,
+					},
+				})
+			}
+		})
+	}
+
+	group.Go(func() {
+		policyViews, err := c.ListPolicies()
+		if err != nil {
+			// a project without policies directory has no policies to index
+			if exists, existsErr := c.projectDirectoryExists("policies"); existsErr != nil || exists {
+				reportError(fmt.Errorf("cannot list policies: %v", err))
+			}
+			return
+		}
+		for _, policyView := range policyViews {
+			policyID := policyView.ID
+			group.Go(func() {
+				policyText, err := c.GetPolicyString(policyID)
+				if err != nil {
+					reportError(fmt.Errorf("cannot get policy '%d': %v", policyID, err))
+					return
+				}
+				policy, err := models.ParsePolicyDocument(policyText)
+				if err != nil {
+					reportError(fmt.Errorf("cannot parse policy '%d': %v", policyID, err))
+					return
+				}
+				policyKey := path.Join(c.projectPath, "policies", strconv.FormatUint(policyID, 10))
+				addDocument(models.NewPolicySearchDocument(policyID, policyKey, *policy))
+			})
+		}
+	})
+
+	entries, err := c.listAllServiceConfigs()
+	group.Wait()
+	if err != nil {
+		return nil, err
+	}
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	for _, entry := range entries {
+		serviceConfigKey := c.getServiceConfigKey(entry.clusterID, entry.applicationID, entry.serviceID)
+		documents = append(documents, models.NewServiceSearchDocument(entry.clusterID, entry.applicationID, entry.serviceID, serviceConfigKey, *entry.serviceConfig))
+	}
+
+	models.SortSearchDocuments(documents)
+	return &models.SearchIndex{
+		BuiltAt:   time.Now().UTC(),
+		Documents: documents,
+	}, nil
+}
@@ -0,0 +1,59 @@
+package core
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/magneticio/forklift/models"
+)
+
+// PutSecret - puts project secret which can be referenced in policy hook headers
+func (c *Core) PutSecret(secretName string, secretValue string) error {
+	if err := models.ValidateSecretName(secretName); err != nil {
+		return err
+	}
+	return c.kvClient.Put(c.getSecretKey(secretName), secretValue)
+}
+
+// DeleteSecret - deletes project secret
+func (c *Core) DeleteSecret(secretName string) error {
+	if err := models.ValidateSecretName(secretName); err != nil {
+		return err
+	}
+	secretKey := c.getSecretKey(secretName)
+	exists, err := c.kvClient.Exists(secretKey)
+	if err != nil {
+		return fmt.Errorf("cannot check if secret exists: %v", err)
+	}
+	if !exists {
+		return fmt.Errorf("secret '%s' does not exist", secretName)
+	}
+	return c.kvClient.Delete(secretKey)
+}
+
+// checkPolicySecrets - checks that secrets referenced in policy hook headers exist
+func (c *Core) checkPolicySecrets(policyText string) error {
+	policy, err := models.ParsePolicyDocument(policyText)
+	if err != nil {
+		// invalid policies are reported by policy validation
+		return nil
+	}
+	for _, reference := range models.FindSecretReferences(*policy) {
+		secretName, err := models.GetSecretReferenceName(reference)
+		if err != nil {
+			return err
+		}
+		exists, err := c.kvClient.Exists(c.getSecretKey(secretName))
+		if err != nil {
+			return fmt.Errorf("cannot check if secret exists: %v", err)
+		}
+		if !exists {
+			return fmt.Errorf("secret '%s' referenced in policy hook headers does not exist", reference)
+		}
+	}
+	return nil
+}
+
+func (c *Core) getSecretKey(secretName string) string {
+	return path.Join(c.projectPath, "secrets", secretName)
+}
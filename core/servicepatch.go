@@ -0,0 +1,66 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/magneticio/forklift/models"
+	"github.com/magneticio/forklift/util"
+)
+
+// PatchServiceConfig - applies merge or json patch to stored service config and puts the result
+// patched service config is validated like a put one, previous and patched configs are returned
+// as indented JSON with sorted keys so that they can be compared line by line,
+// patching fails if the stored service config changes while the patch is applied
+func (c *Core) PatchServiceConfig(applicationID, serviceID uint64, patchType, patch string, allowShared bool) (string, string, error) {
+	serviceConfigText, err := c.GetServiceConfigText(serviceID, applicationID)
+	if err != nil {
+		return "", "", err
+	}
+
+	patchedText, err := util.ApplyPatch(patchType, serviceConfigText, patch)
+	if err != nil {
+		return "", "", fmt.Errorf("cannot patch service config: %v", err)
+	}
+	var patchedServiceConfig models.ServiceConfig
+	if err := json.Unmarshal([]byte(patchedText), &patchedServiceConfig); err != nil {
+		return "", "", fmt.Errorf("cannot deserialize patched service config: %v", err)
+	}
+	if patchedServiceConfig.ApplicationID == nil || *patchedServiceConfig.ApplicationID != applicationID ||
+		patchedServiceConfig.ServiceID == nil || *patchedServiceConfig.ServiceID != serviceID {
+		return "", "", fmt.Errorf("patch must not change application_id or service_id")
+	}
+
+	previous, err := util.CanonicalJSON(serviceConfigText)
+	if err != nil {
+		return "", "", fmt.Errorf("cannot format service config: %v", err)
+	}
+	patched, err := util.CanonicalJSON(patchedText)
+	if err != nil {
+		return "", "", fmt.Errorf("cannot format patched service config: %v", err)
+	}
+	if previous == patched {
+		return previous, patched, nil
+	}
+
+	checkUnchanged := func(serviceConfigKey string) error {
+		return c.checkServiceConfigUnchanged(serviceConfigKey, serviceConfigText)
+	}
+	if err := c.putServiceConfig(patchedText, allowShared, checkUnchanged); err != nil {
+		return "", "", err
+	}
+	return previous, patched, nil
+}
+
+// checkServiceConfigUnchanged - compares stored service config with the one which has been patched
+// the key value store has no transactions so a concurrent change between this check and the put is still possible
+func (c *Core) checkServiceConfigUnchanged(serviceConfigKey, serviceConfigText string) error {
+	currentText, err := c.kvClient.Get(serviceConfigKey)
+	if err != nil {
+		return fmt.Errorf("cannot get service config: %v", err)
+	}
+	if currentText != serviceConfigText {
+		return fmt.Errorf("service config has been changed concurrently, patch it again")
+	}
+	return nil
+}
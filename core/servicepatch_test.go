@@ -0,0 +1,19 @@
+package core
+
+import "testing"
+
+func TestCheckServiceConfigUnchanged(t *testing.T) {
+	serviceConfigKey := "vamp/projects/1/clusters/1/applications/2/service-configs/5"
+	store := memoryKeyValueStore{
+		serviceConfigKey: `{"application_id":2,"service_id":5}`,
+	}
+	core := newMemoryCore(store, nil)
+
+	if err := core.checkServiceConfigUnchanged(serviceConfigKey, `{"application_id":2,"service_id":5}`); err != nil {
+		t.Errorf("checkServiceConfigUnchanged() of unchanged service config error = %v", err)
+	}
+	store[serviceConfigKey] = `{"application_id":2,"service_id":5,"k8s_namespace":"shop"}`
+	if err := core.checkServiceConfigUnchanged(serviceConfigKey, `{"application_id":2,"service_id":5}`); err == nil {
+		t.Errorf("checkServiceConfigUnchanged() of concurrently changed service config should fail")
+	}
+}
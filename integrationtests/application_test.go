@@ -70,6 +70,28 @@ func TestIntegrationApplicationCommands(t *testing.T) {
 				Convey("response should contain application definition", func() {
 					So(stdoutLines[0], ShouldEqual, "id: 12345")
 					So(stdoutLines[1], ShouldEqual, "namespace: test-namespace")
+					So(stdoutLines[2], ShouldEqual, "namespaces:")
+					So(stdoutLines[3], ShouldEqual, "    - test-namespace")
+				})
+			})
+
+			Convey("and describing application", func() {
+				describeApplicationCommand := fmt.Sprintf(
+					"describe application %d --cluster %d",
+					applicationID,
+					clusterID,
+				)
+				stdoutLines, err := runCommand(describeApplicationCommand)
+
+				Convey("error should not be thrown", func() {
+					So(err, ShouldBeNil)
+				})
+
+				Convey("response should contain application description", func() {
+					So(stdoutLines[0], ShouldEqual, "id: 12345")
+					So(stdoutLines[1], ShouldEqual, "namespaces:")
+					So(stdoutLines[2], ShouldEqual, "    - test-namespace")
+					So(stdoutLines[3], ShouldEqual, "services: []")
 				})
 			})
 
@@ -87,6 +109,107 @@ func TestIntegrationApplicationCommands(t *testing.T) {
 				Convey("response should contain list of applications", func() {
 					So(stdoutLines[0], ShouldEqual, "- id: 12345")
 					So(stdoutLines[1], ShouldEqual, "  namespace: test-namespace")
+					So(stdoutLines[2], ShouldEqual, "  namespaces:")
+					So(stdoutLines[3], ShouldEqual, "    - test-namespace")
+				})
+			})
+
+			Convey("and adding another namespace to it", func() {
+				addNamespaceCommand := fmt.Sprintf(
+					"application add-namespace %d %s --cluster %d",
+					applicationID,
+					"test-namespace-2",
+					clusterID,
+				)
+				stdoutLines, err := runCommand(addNamespaceCommand)
+
+				Convey("error should not be thrown", func() {
+					So(err, ShouldBeNil)
+				})
+
+				Convey("response should contain information that namespace has been added", func() {
+					So(stdoutLines[0], ShouldEqual, "Namespace 'test-namespace-2' has been added to application '12345'")
+				})
+
+				Convey("and showing application", func() {
+					stdoutLines, err := runCommand(fmt.Sprintf("show application %d --cluster %d", applicationID, clusterID))
+
+					Convey("response should list both namespaces", func() {
+						So(err, ShouldBeNil)
+						So(stdoutLines[1], ShouldEqual, "namespace: test-namespace")
+						So(stdoutLines[2], ShouldEqual, "namespaces:")
+						So(stdoutLines[3], ShouldEqual, "    - test-namespace")
+						So(stdoutLines[4], ShouldEqual, "    - test-namespace-2")
+					})
+				})
+
+				Convey("and removing it afterwards", func() {
+					removeNamespaceCommand := fmt.Sprintf(
+						"application remove-namespace %d %s --cluster %d",
+						applicationID,
+						"test-namespace-2",
+						clusterID,
+					)
+					stdoutLines, err := runCommand(removeNamespaceCommand)
+
+					Convey("error should not be thrown", func() {
+						So(err, ShouldBeNil)
+					})
+
+					Convey("response should contain information that namespace has been removed", func() {
+						So(stdoutLines[0], ShouldEqual, "Namespace 'test-namespace-2' has been removed from application '12345'")
+					})
+
+					Convey("application should be saved to Vault with the original namespace", func() {
+						clusterConfig, _, err := readValueFromVault("/v1/secret/vamp/projects/1/clusters/4321/release-agent-config")
+						snapshot, _ := readSnapshot("./snapshots/applicationconfig.txt")
+						So(err, ShouldBeNil)
+						So(clusterConfig, ShouldEqual, snapshot)
+					})
+				})
+			})
+
+			Convey("and removing its only namespace", func() {
+				removeNamespaceCommand := fmt.Sprintf(
+					"application remove-namespace %d %s --cluster %d",
+					applicationID,
+					namespace,
+					clusterID,
+				)
+				_, err := runCommand(removeNamespaceCommand)
+
+				Convey("error should be thrown", func() {
+					So(err.Error(), ShouldEqual, "namespace 'test-namespace' is the last namespace of application '12345', delete the application instead")
+				})
+			})
+
+			Convey("and putting another application with the same namespace", func() {
+				otherApplicationCommand := fmt.Sprintf(
+					"put application %d --namespace %s --cluster %d",
+					applicationID+1,
+					namespace,
+					clusterID,
+				)
+				_, err := runCommand(otherApplicationCommand)
+
+				Convey("error should name the current owner of the namespace", func() {
+					So(err.Error(), ShouldEqual, "namespace 'test-namespace' is already used by application '12345', use --force to reassign it")
+				})
+
+				Convey("and putting it with force flag", func() {
+					Reset(func() {
+						runCommand(fmt.Sprintf("delete application %d --cluster %d", applicationID+1, clusterID))
+					})
+
+					stdoutLines, err := runCommand(otherApplicationCommand + " --force")
+
+					Convey("error should not be thrown", func() {
+						So(err, ShouldBeNil)
+					})
+
+					Convey("response should contain information that application has been put", func() {
+						So(stdoutLines[0], ShouldEqual, "Application '12346' has been put")
+					})
 				})
 			})
 
@@ -113,6 +236,45 @@ func TestIntegrationApplicationCommands(t *testing.T) {
 					So(clusterConfig, ShouldEqual, snapshot)
 				})
 			})
+
+			Convey("and deleting it with cascade after putting a release plan", func() {
+				putReleasePlanCommand := fmt.Sprintf(
+					"put releaseplan 1.0.5 --cluster %d --application %d --service 56789 --file ./resources/releaseplan.json",
+					clusterID,
+					applicationID,
+				)
+				_, err := runCommand(putReleasePlanCommand)
+				So(err, ShouldBeNil)
+
+				deleteApplicationCommand := fmt.Sprintf(
+					"delete application %d --cluster %d --cascade --yes",
+					applicationID,
+					clusterID,
+				)
+				stdoutLines, err := runCommand(deleteApplicationCommand)
+
+				Convey("error should not be thrown", func() {
+					So(err, ShouldBeNil)
+				})
+
+				Convey("response should contain the deleted keys", func() {
+					So(stdoutLines[0], ShouldEqual, "The following keys will be deleted:")
+					So(stdoutLines[1], ShouldEqual, "/secret/vamp/projects/1/clusters/4321/applications/12345")
+					So(stdoutLines[2], ShouldEqual, "└── release-plans")
+					So(stdoutLines[3], ShouldEqual, "    └── 56789")
+					So(stdoutLines[4], ShouldEqual, "        └── 1.0.5")
+					So(stdoutLines[5], ShouldEqual, "Application '12345' has been deleted")
+				})
+
+				Convey("release plan should be removed from Vault", func() {
+					_, err := runCommand(fmt.Sprintf(
+						"show releaseplan 1.0.5 --cluster %d --application %d --service 56789",
+						clusterID,
+						applicationID,
+					))
+					So(err, ShouldNotBeNil)
+				})
+			})
 		})
 	})
 
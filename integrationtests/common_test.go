@@ -174,7 +174,7 @@ func runCommand(commandText string) ([]string, error) {
 // if it's not executed, errors for missing mandatory flags are not being thrown
 func resetFlags(cmd *cobra.Command) {
 	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
-		if flag.Value.Type() == "stringSlice" {
+		if flag.Value.Type() == "stringSlice" || flag.Value.Type() == "stringArray" {
 			// XXX: unfortunately, flag.Value.Set() appends to original
 			// slice, not resets it, so we retrieve pointer to the slice here
 			// and set it to new empty slice manually
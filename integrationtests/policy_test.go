@@ -55,6 +55,18 @@ func TestIntegrationPolicyCommands(t *testing.T) {
 			})
 		})
 
+		Convey("and showing policy with revealed headers", func() {
+			stdoutLines, err := runCommand(fmt.Sprintf("show policy %d --reveal", policyID))
+
+			Convey("error should not be thrown", func() {
+				So(err, ShouldBeNil)
+			})
+
+			Convey("response should contain header values", func() {
+				So(toText(stdoutLines), ShouldContainSubstring, `"authorization: Bearer xxxyyy"`)
+			})
+		})
+
 		Convey("and listing policies", func() {
 			stdoutLines, err := runCommand("list policies")
 
@@ -90,6 +102,207 @@ func TestIntegrationPolicyCommands(t *testing.T) {
 		})
 	})
 
+	Convey("When executing put policy command with policy name", t, func() {
+		command := fmt.Sprintf(
+			"put policy %d --name canary-standard --file %s",
+			policyID,
+			validPolicyFilePath,
+		)
+		stdoutLines, err := runCommand(command)
+
+		Convey("error should not be thrown", func() {
+			So(err, ShouldBeNil)
+		})
+
+		Convey("response should contain information that policy has been put", func() {
+			So(stdoutLines[0], ShouldEqual, "Policy '456' has been put")
+		})
+
+		Convey("and listing policies", func() {
+			stdoutLines, err := runCommand("list policies")
+
+			Convey("error should not be thrown", func() {
+				So(err, ShouldBeNil)
+			})
+
+			Convey("response should contain policy name", func() {
+				So(stdoutLines[0], ShouldEqual, "- id: 456")
+				So(stdoutLines[1], ShouldEqual, "  name: canary-standard")
+				So(stdoutLines[2], ShouldEqual, "  type: release")
+			})
+		})
+
+		Convey("and showing policy by name", func() {
+			stdoutLines, err := runCommand("show policy --name canary-standard")
+
+			Convey("error should not be thrown", func() {
+				So(err, ShouldBeNil)
+			})
+
+			Convey("response should contain policy", func() {
+				snapshot, _ := readSnapshot("./snapshots/policy_show.txt")
+				So(toText(stdoutLines), ShouldEqual, snapshot)
+			})
+		})
+
+		Convey("and deleting it by name afterwards", func() {
+			stdoutLines, err := runCommand("delete policy --name canary-standard")
+
+			Convey("error should not be thrown", func() {
+				So(err, ShouldBeNil)
+			})
+
+			Convey("response should contain information that policy has been deleted", func() {
+				So(stdoutLines[0], ShouldEqual, "Policy '456' has been deleted")
+			})
+		})
+	})
+
+	Convey("When executing delete policy command for policy referenced by a service config", t, func() {
+		_, err := runCommand("put cluster 31 --name test-cluster --nats-channel-name nats-channel --optimiser-nats-channel-name optimiser-channel")
+		So(err, ShouldBeNil)
+		_, err = runCommand("put application 31 --namespace referencing-namespace --cluster 31")
+		So(err, ShouldBeNil)
+		_, err = runCommand(fmt.Sprintf("put policy %d --file %s", policyID, validPolicyFilePath))
+		So(err, ShouldBeNil)
+		_, err = runCommand("put service --cluster 31 --file ./resources/referencingservice.json")
+		So(err, ShouldBeNil)
+
+		Reset(func() {
+			runCommand("delete service 311 --cluster 31 --application 31")
+			runCommand("delete application 31 --cluster 31")
+			runCommand("delete cluster 31")
+		})
+
+		_, err = runCommand(fmt.Sprintf("delete policy %d", policyID))
+
+		Convey("error should list referencing service configs", func() {
+			So(err.Error(), ShouldEqual, "policy '456' is referenced by service configs:\ncluster '31', application '31', service '311' (default_policy_id)\nuse --force to delete it anyway")
+		})
+
+		Convey("and deleting it with force flag", func() {
+			stdoutLines, err := runCommand(fmt.Sprintf("delete policy %d --force", policyID))
+
+			Convey("error should not be thrown", func() {
+				So(err, ShouldBeNil)
+			})
+
+			Convey("response should contain information that policy has been deleted", func() {
+				So(stdoutLines[0], ShouldEqual, "Policy '456' has been deleted")
+			})
+		})
+	})
+
+	Convey("When executing policy usages command", t, func() {
+		_, err := runCommand("put cluster 31 --name test-cluster --nats-channel-name nats-channel --optimiser-nats-channel-name optimiser-channel")
+		So(err, ShouldBeNil)
+		_, err = runCommand("put application 31 --namespace referencing-namespace --cluster 31")
+		So(err, ShouldBeNil)
+		_, err = runCommand(fmt.Sprintf("put policy %d --file %s", policyID, validPolicyFilePath))
+		So(err, ShouldBeNil)
+		_, err = runCommand(fmt.Sprintf("put policy %d --file %s", policyID+1, validPolicyFilePath))
+		So(err, ShouldBeNil)
+		_, err = runCommand("put service --cluster 31 --file ./resources/referencingservice.json")
+		So(err, ShouldBeNil)
+
+		Reset(func() {
+			runCommand("delete cluster 31 --cascade --yes")
+			runCommand(fmt.Sprintf("delete policy %d", policyID))
+			runCommand(fmt.Sprintf("delete policy %d", policyID+1))
+		})
+
+		Convey("for policy referenced by a service config", func() {
+			stdoutLines, err := runCommand(fmt.Sprintf("policy usages %d", policyID))
+
+			Convey("error should not be thrown", func() {
+				So(err, ShouldBeNil)
+			})
+
+			Convey("response should contain referencing service config", func() {
+				So(stdoutLines[0], ShouldEqual, "- cluster: 31")
+				So(stdoutLines[1], ShouldEqual, "  application: 31")
+				So(stdoutLines[2], ShouldEqual, "  service: 311")
+				So(stdoutLines[3], ShouldEqual, "  slot: default")
+			})
+		})
+
+		Convey("for policy which is not referenced", func() {
+			stdoutLines, err := runCommand(fmt.Sprintf("policy usages %d", policyID+1))
+
+			Convey("error should not be thrown", func() {
+				So(err, ShouldBeNil)
+			})
+
+			Convey("response should contain empty list", func() {
+				So(stdoutLines[0], ShouldEqual, "[]")
+			})
+		})
+
+		Convey("with unused flag", func() {
+			stdoutLines, err := runCommand("policy usages --unused")
+
+			Convey("error should not be thrown", func() {
+				So(err, ShouldBeNil)
+			})
+
+			Convey("response should contain only the policy which is not referenced", func() {
+				text := toText(stdoutLines)
+				So(text, ShouldContainSubstring, "- id: 457")
+				So(text, ShouldNotContainSubstring, "- id: 456")
+			})
+		})
+
+		Convey("without policy id", func() {
+			_, err := runCommand("policy usages")
+
+			Convey("error should be thrown", func() {
+				So(err.Error(), ShouldEqual, "Not enough arguments - policy id needed")
+			})
+		})
+	})
+
+	Convey("When executing put policy command with policy referencing a secret", t, func() {
+		command := fmt.Sprintf("put policy %d --file ./resources/secretpolicy.json", policyID)
+
+		Convey("error should be thrown if the secret does not exist", func() {
+			_, err := runCommand(command)
+			So(err.Error(), ShouldEqual, "secret 'project/hooks/test' referenced in policy hook headers does not exist")
+		})
+
+		Convey("and putting the secret first", func() {
+			stdoutLines, err := runCommand("put secret hooks/test --file ./resources/secretvalue.txt")
+			So(err, ShouldBeNil)
+			So(stdoutLines[0], ShouldEqual, "Secret 'hooks/test' has been put")
+
+			Reset(func() {
+				runCommand(fmt.Sprintf("delete policy %d", policyID))
+				runCommand("delete secret hooks/test")
+			})
+
+			stdoutLines, err = runCommand(command)
+
+			Convey("error should not be thrown", func() {
+				So(err, ShouldBeNil)
+			})
+
+			Convey("response should contain information that policy has been put", func() {
+				So(stdoutLines[0], ShouldEqual, "Policy '456' has been put")
+			})
+
+			Convey("secret should be saved to Vault", func() {
+				secret, _, err := readValueFromVault("/v1/secret/vamp/projects/1/secrets/hooks/test")
+				So(err, ShouldBeNil)
+				So(secret, ShouldEqual, `"xxxyyy"`)
+			})
+
+			Convey("and showing policy keeps secret reference", func() {
+				stdoutLines, err := runCommand(fmt.Sprintf("show policy %d", policyID))
+				So(err, ShouldBeNil)
+				So(toText(stdoutLines), ShouldContainSubstring, `"authorization: Bearer ${secret:project/hooks/test}"`)
+			})
+		})
+	})
+
 	Convey("When executing put policy command with invalid policy", t, func() {
 		command := fmt.Sprintf(
 			"put policy %d --file %s",
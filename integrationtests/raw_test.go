@@ -0,0 +1,70 @@
+// +build integration
+
+package integrationtests
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestIntegrationRawCommands(t *testing.T) {
+	Convey("When executing raw put command without confirmation flag", t, func() {
+		_, err := runCommand("raw put raw-test/key --value test-value")
+
+		Convey("error should be thrown", func() {
+			So(err.Error(), ShouldEqual, "Raw writes bypass all validation, use --i-know-what-im-doing flag to proceed")
+		})
+	})
+
+	Convey("When executing raw put command with confirmation flag", t, func() {
+		stdoutLines, err := runCommand("raw put raw-test/key --value test-value --i-know-what-im-doing")
+
+		Convey("error should not be thrown", func() {
+			So(err, ShouldBeNil)
+		})
+
+		Convey("response should contain information that key has been put", func() {
+			So(stdoutLines[0], ShouldEqual, "Key 'raw-test/key' has been put")
+		})
+
+		Convey("and getting the key", func() {
+			stdoutLines, err := runCommand("raw get raw-test/key")
+
+			Convey("response should contain the value", func() {
+				So(err, ShouldBeNil)
+				So(stdoutLines[0], ShouldEqual, "test-value")
+			})
+		})
+
+		Convey("and showing the project tree", func() {
+			stdoutLines, err := runCommand("tree")
+
+			Convey("response should contain the key", func() {
+				So(err, ShouldBeNil)
+				So(stdoutLines[0], ShouldEqual, "/secret/vamp/projects/1")
+				So(toText(stdoutLines), ShouldContainSubstring, "raw-test\n")
+			})
+		})
+
+		Convey("and deleting the key", func() {
+			stdoutLines, err := runCommand("raw delete raw-test/key --i-know-what-im-doing")
+
+			Convey("error should not be thrown", func() {
+				So(err, ShouldBeNil)
+			})
+
+			Convey("response should contain information that key has been deleted", func() {
+				So(stdoutLines[0], ShouldEqual, "Key 'raw-test/key' has been deleted")
+			})
+		})
+	})
+
+	Convey("When executing raw get command with key outside of the project", t, func() {
+		_, err := runCommand("raw get ../2/policies/1")
+
+		Convey("error should be thrown", func() {
+			So(err.Error(), ShouldEqual, "key '../2/policies/1' is outside of the project")
+		})
+	})
+}
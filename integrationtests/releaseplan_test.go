@@ -180,4 +180,93 @@ func TestIntegrationReleasePlanCommands(t *testing.T) {
 			So(err.Error(), ShouldEqual, `required flag(s) "file" not set`)
 		})
 	})
+
+	Convey("When executing releaseplan generate command for a service", t, func() {
+		_, err := runCommand("put cluster 41 --name test-cluster --nats-channel-name nats-channel --optimiser-nats-channel-name optimiser-channel")
+		So(err, ShouldBeNil)
+		_, err = runCommand("put application 41 --namespace releaseplan-namespace --cluster 41")
+		So(err, ShouldBeNil)
+		_, err = runCommand("put policy 456 --file ./resources/validpolicy.json")
+		So(err, ShouldBeNil)
+		_, err = runCommand("put service --cluster 41 --file ./resources/releaseplanservice.json")
+		So(err, ShouldBeNil)
+
+		Reset(func() {
+			runCommand("delete cluster 41 --cascade --yes")
+			runCommand("delete policy 456 --force")
+		})
+
+		Convey("without release groups and previous release plan", func() {
+			_, err := runCommand("releaseplan generate 1.0.0 --cluster 41 --application 41 --service 411")
+
+			Convey("error should be thrown", func() {
+				So(err.Error(), ShouldEqual, "release groups must be provided as there is no previous release plan to copy them from")
+			})
+		})
+
+		Convey("with release groups", func() {
+			stdoutLines, err := runCommand("releaseplan generate 1.0.0 --cluster 41 --application 41 --service 411 --group dev --group test,prod")
+
+			Convey("error should not be thrown", func() {
+				So(err, ShouldBeNil)
+			})
+
+			Convey("response should contain not started release plan named after the app label", func() {
+				text := toText(stdoutLines)
+				So(stdoutLines[0], ShouldEqual, "{")
+				So(stdoutLines[1], ShouldEqual, `    "status": "not started",`)
+				So(text, ShouldContainSubstring, `"name": "nginx-release",`)
+				So(text, ShouldContainSubstring, `"version": "1.0.0"`)
+				So(text, ShouldContainSubstring, `"name": "group-2",`)
+				So(text, ShouldContainSubstring, `"id": "prod",`)
+			})
+
+			Convey("release plan should not be saved to Vault", func() {
+				_, exists, _ := readValueFromVault("/v1/secret/vamp/projects/1/clusters/41/applications/41/release-plans/411/1.0.0")
+				So(exists, ShouldEqual, false)
+			})
+		})
+
+		Convey("with put flag", func() {
+			stdoutLines, err := runCommand("releaseplan generate 1.0.0 --cluster 41 --application 41 --service 411 --group dev --group test,prod --put")
+
+			Convey("error should not be thrown", func() {
+				So(err, ShouldBeNil)
+			})
+
+			Convey("response should contain selected policy and information that release plan has been put", func() {
+				So(stdoutLines[0], ShouldEqual, "Release plan for initial version bump uses default policy '456'")
+				So(stdoutLines[1], ShouldEqual, "Release plan for service version '1.0.0' has been put")
+			})
+
+			Convey("release plan should be saved to Vault", func() {
+				_, exists, err := readValueFromVault("/v1/secret/vamp/projects/1/clusters/41/applications/41/release-plans/411/1.0.0")
+				So(err, ShouldBeNil)
+				So(exists, ShouldEqual, true)
+			})
+
+			Convey("and generating release plan of the next minor version", func() {
+				stdoutLines, err := runCommand("releaseplan generate 1.1.0 --cluster 41 --application 41 --service 411")
+
+				Convey("error should not be thrown", func() {
+					So(err, ShouldBeNil)
+				})
+
+				Convey("response should contain release groups copied from the previous release plan", func() {
+					text := toText(stdoutLines)
+					So(text, ShouldContainSubstring, `"version": "1.1.0"`)
+					So(text, ShouldContainSubstring, `"id": "dev",`)
+					So(text, ShouldContainSubstring, `"id": "prod",`)
+				})
+			})
+
+			Convey("and generating release plan of an older version", func() {
+				_, err := runCommand("releaseplan generate 0.9.0 --cluster 41 --application 41 --service 411")
+
+				Convey("error should be thrown", func() {
+					So(err.Error(), ShouldEqual, "version '0.9.0' is not newer than version '1.0.0'")
+				})
+			})
+		})
+	})
 }
@@ -0,0 +1,110 @@
+// +build integration
+
+package integrationtests
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestIntegrationResolveCommands(t *testing.T) {
+	Convey("When executing resolve policy command for a service", t, func() {
+		_, err := runCommand("put cluster 42 --name test-cluster --nats-channel-name nats-channel --optimiser-nats-channel-name optimiser-channel")
+		So(err, ShouldBeNil)
+		_, err = runCommand("put application 42 --namespace resolve-namespace --cluster 42")
+		So(err, ShouldBeNil)
+		_, err = runCommand("put policy 456 --file ./resources/validpolicy.json")
+		So(err, ShouldBeNil)
+		_, err = runCommand("put policy 457 --file ./resources/validpolicy.json")
+		So(err, ShouldBeNil)
+		_, err = runCommand("put service --cluster 42 --file ./resources/resolveservice.json")
+		So(err, ShouldBeNil)
+
+		Reset(func() {
+			runCommand("delete cluster 42 --cascade --yes")
+			runCommand("delete policy 456 --force")
+			runCommand("delete policy 457 --force")
+		})
+
+		Convey("without previous version", func() {
+			stdoutLines, err := runCommand("resolve policy --cluster 42 --application 42 --service 421 --to 1.0.0")
+
+			Convey("error should not be thrown", func() {
+				So(err, ShouldBeNil)
+			})
+
+			Convey("response should contain default policy used for the initial version", func() {
+				So(stdoutLines[0], ShouldEqual, "Version bump: initial (-> 1.0.0)")
+				So(stdoutLines[1], ShouldEqual, "Policy: 456")
+				So(stdoutLines[2], ShouldEqual, "Selection: default fallback")
+				So(stdoutLines[3], ShouldEqual, "Reason: there is no previous version, default_policy_id is used")
+				So(stdoutLines[4], ShouldEqual, "{")
+			})
+
+			Convey("response should contain policy with masked header values", func() {
+				text := toText(stdoutLines)
+				So(text, ShouldContainSubstring, `"authorization: ******"`)
+				So(text, ShouldNotContainSubstring, "xxxyyy")
+			})
+		})
+
+		Convey("with minor version bump", func() {
+			stdoutLines, err := runCommand("resolve policy --cluster 42 --application 42 --service 421 --from 1.0.0 --to 1.1.0")
+
+			Convey("error should not be thrown", func() {
+				So(err, ShouldBeNil)
+			})
+
+			Convey("response should contain explicitly selected minor policy", func() {
+				So(stdoutLines[0], ShouldEqual, "Version bump: minor (1.0.0 -> 1.1.0)")
+				So(stdoutLines[1], ShouldEqual, "Policy: 457")
+				So(stdoutLines[2], ShouldEqual, "Selection: explicit")
+				So(stdoutLines[3], ShouldEqual, "Reason: minor_policy_id is defined in service config")
+			})
+		})
+
+		Convey("with patch version bump", func() {
+			stdoutLines, err := runCommand("resolve policy --cluster 42 --application 42 --service 421 --from 1.0.0 --to 1.0.1")
+
+			Convey("error should not be thrown", func() {
+				So(err, ShouldBeNil)
+			})
+
+			Convey("response should contain default policy as fallback", func() {
+				So(stdoutLines[0], ShouldEqual, "Version bump: patch (1.0.0 -> 1.0.1)")
+				So(stdoutLines[1], ShouldEqual, "Policy: 456")
+				So(stdoutLines[2], ShouldEqual, "Selection: default fallback")
+				So(stdoutLines[3], ShouldEqual, "Reason: patch_policy_id is not defined in service config, falling back to default_policy_id")
+			})
+		})
+
+		Convey("with revealed headers", func() {
+			stdoutLines, err := runCommand("resolve policy --cluster 42 --application 42 --service 421 --to 1.0.0 --reveal")
+
+			Convey("error should not be thrown", func() {
+				So(err, ShouldBeNil)
+			})
+
+			Convey("response should contain header values", func() {
+				So(toText(stdoutLines), ShouldContainSubstring, `"authorization: Bearer xxxyyy"`)
+			})
+		})
+
+		Convey("with version which is not newer", func() {
+			_, err := runCommand("resolve policy --cluster 42 --application 42 --service 421 --from 1.1.0 --to 1.0.0")
+
+			Convey("error should be thrown", func() {
+				So(err.Error(), ShouldEqual, "version '1.0.0' is not newer than version '1.1.0'")
+			})
+		})
+	})
+
+	Convey("When executing resolve policy command without to flag", t, func() {
+		_, err := runCommand("resolve policy --cluster 42 --application 42 --service 421")
+
+		Convey("error should be thrown", func() {
+			So(err.Error(), ShouldEqual, `required flag(s) "to" not set`)
+		})
+	})
+}
@@ -15,8 +15,14 @@ func TestIntegrationServiceCommands(t *testing.T) {
 	var serviceID = uint64(4555)
 	var validServicePath = "./resources/validservice.json"
 	var invalidServicePath = "./resources/invalidservice.json"
+	var missingPolicyServicePath = "./resources/missingpolicyservice.json"
+	var validPolicyPath = "./resources/validpolicy.json"
+	var defaultPolicyID = uint64(1)
 
 	Convey("When executing put service command with valid service config", t, func() {
+		_, err := runCommand(fmt.Sprintf("put policy %d --file %s", defaultPolicyID, validPolicyPath))
+		So(err, ShouldBeNil)
+
 		command := fmt.Sprintf(
 			"put service --cluster %d --file %s",
 			clusterID,
@@ -72,7 +78,71 @@ func TestIntegrationServiceCommands(t *testing.T) {
 			})
 
 			Convey("response should contain services list", func() {
-				So(stdoutLines[0], ShouldEqual, "- 4555")
+				So(stdoutLines[0], ShouldEqual, "- id: 4555")
+				So(stdoutLines[1], ShouldEqual, "  namespace: test")
+			})
+		})
+
+		Convey("and searching by its domain", func() {
+			stdoutLines, err := runCommand("search domain:test.local label:app=nginx-test")
+
+			Convey("error should not be thrown", func() {
+				So(err, ShouldBeNil)
+			})
+
+			Convey("response should contain the service with its coordinates", func() {
+				So(toText(stdoutLines), ShouldContainSubstring, "coordinates: cluster 889 / application 112 / service 4555")
+			})
+		})
+
+		Convey("and listing services with label selector", func() {
+			listServicesCommand := fmt.Sprintf(
+				"list services --cluster %d --application %d -l app=other",
+				clusterID,
+				applicationID,
+			)
+			stdoutLines, err := runCommand(listServicesCommand)
+
+			Convey("error should not be thrown", func() {
+				So(err, ShouldBeNil)
+			})
+
+			Convey("response should contain empty services list", func() {
+				So(stdoutLines[0], ShouldEqual, "[]")
+			})
+		})
+
+		Convey("and patching its ingress port", func() {
+			patchServiceCommand := fmt.Sprintf(
+				`patch service --cluster %d --application %d --service %d --type json --patch [{"op":"replace","path":"/ingress_rules/0/port","value":8080}]`,
+				clusterID,
+				applicationID,
+				serviceID,
+			)
+			stdoutLines, err := runCommand(patchServiceCommand)
+
+			Convey("error should not be thrown", func() {
+				So(err, ShouldBeNil)
+			})
+
+			Convey("response should contain changed lines", func() {
+				So(stdoutLines, ShouldContain, `-             "port": 8081`)
+				So(stdoutLines, ShouldContain, `+             "port": 8080`)
+				So(stdoutLines[len(stdoutLines)-1], ShouldEqual, "Service '4555' has been patched")
+			})
+		})
+
+		Convey("and patching it with invalid namespace", func() {
+			patchServiceCommand := fmt.Sprintf(
+				`patch service --cluster %d --application %d --service %d --patch {"k8s_namespace":"Test"}`,
+				clusterID,
+				applicationID,
+				serviceID,
+			)
+			_, err := runCommand(patchServiceCommand)
+
+			Convey("error should be thrown", func() {
+				So(err.Error(), ShouldStartWith, "service config validation failed: k8s_namespace field must be a lowercase RFC 1123 label")
 			})
 		})
 
@@ -113,6 +183,35 @@ func TestIntegrationServiceCommands(t *testing.T) {
 		})
 	})
 
+	Convey("When executing put service command with service config referencing missing policy", t, func() {
+		command := fmt.Sprintf(
+			"put service --cluster %d --file %s",
+			clusterID,
+			missingPolicyServicePath,
+		)
+		_, err := runCommand(command)
+
+		Convey("error should be thrown", func() {
+			So(err.Error(), ShouldEqual, "service config validation failed: policy '999' referenced by default_policy_id does not exist")
+		})
+	})
+
+	Convey("When executing put service command with release policy referenced as validation policy", t, func() {
+		_, err := runCommand(fmt.Sprintf("put policy %d --file %s", defaultPolicyID, validPolicyPath))
+		So(err, ShouldBeNil)
+
+		command := fmt.Sprintf(
+			"put service --cluster %d --file %s",
+			clusterID,
+			"./resources/validationpolicyservice.json",
+		)
+		_, err = runCommand(command)
+
+		Convey("error should be thrown", func() {
+			So(err.Error(), ShouldEqual, "service config validation failed: policy '1' referenced by validation_policy_ids is a release policy, validation policy expected")
+		})
+	})
+
 	Convey("When executing put service command without cluster", t, func() {
 		command := fmt.Sprintf(
 			"put service --file %s",
@@ -136,4 +235,51 @@ func TestIntegrationServiceCommands(t *testing.T) {
 			So(err.Error(), ShouldEqual, `required flag(s) "file" not set`)
 		})
 	})
+
+	Convey("When executing put service command with ingress rule used by another service of the cluster", t, func() {
+		var ingressClusterID = uint64(890)
+		_, err := runCommand(fmt.Sprintf("put policy %d --file %s", defaultPolicyID, validPolicyPath))
+		So(err, ShouldBeNil)
+		_, err = runCommand(fmt.Sprintf(
+			"put cluster %d --name ingress-cluster --nats-channel-name nats-channel --optimiser-nats-channel-name optimiser-channel --nats-token nats-token",
+			ingressClusterID,
+		))
+		So(err, ShouldBeNil)
+		_, err = runCommand(fmt.Sprintf("put application %d --namespace test --cluster %d", applicationID, ingressClusterID))
+		So(err, ShouldBeNil)
+		_, err = runCommand(fmt.Sprintf("put service --cluster %d --file %s", ingressClusterID, validServicePath))
+		So(err, ShouldBeNil)
+
+		command := fmt.Sprintf(
+			"put service --cluster %d --file %s",
+			ingressClusterID,
+			"./resources/sharedingressservice.json",
+		)
+		_, err = runCommand(command)
+
+		Convey("error should be thrown", func() {
+			So(err.Error(), ShouldEqual, "service config validation failed: ingress domain 'test.local' with path '/' is claimed by service '4555' of application '112', service '4556' of application '112', use --allow-shared to put it anyway")
+		})
+
+		Convey("and putting it with allow shared flag", func() {
+			stdoutLines, err := runCommand(command + " --allow-shared")
+
+			Convey("error should not be thrown", func() {
+				So(err, ShouldBeNil)
+				So(stdoutLines[0], ShouldEqual, "Service has been put")
+			})
+
+			Convey("and linting ingress rules of the cluster", func() {
+				_, err := runCommand(fmt.Sprintf("lint ingress --cluster %d", ingressClusterID))
+
+				Convey("conflict should be reported", func() {
+					So(err.Error(), ShouldEqual, "Found 1 ingress conflict(s)")
+				})
+			})
+		})
+
+		Reset(func() {
+			runCommand(fmt.Sprintf("delete cluster %d --cascade --yes", ingressClusterID))
+		})
+	})
 }
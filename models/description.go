@@ -0,0 +1,46 @@
+package models
+
+// ClusterDescription - cluster with all its applications used as an output for describe command
+type ClusterDescription struct {
+	ID                   uint64                   `yaml:"id"`
+	Name                 string                   `yaml:"name"`
+	NatsChannel          string                   `yaml:"nats-channel"`
+	OptimiserNatsChannel string                   `yaml:"optimiser-nats-channel"`
+	Applications         []ApplicationDescription `yaml:"applications"`
+}
+
+// ApplicationDescription - application with all its services used as an output for describe command
+// a failure to list the services of the application is reported in errors
+type ApplicationDescription struct {
+	ID         uint64               `yaml:"id"`
+	Namespaces []string             `yaml:"namespaces"`
+	Services   []ServiceDescription `yaml:"services"`
+	Errors     []string             `yaml:"errors,omitempty"`
+}
+
+// ServiceDescription - service config summary with policies and latest release plan
+// problems with fetching parts of the service are reported in errors instead of failing the whole description
+type ServiceDescription struct {
+	ID                uint64                  `yaml:"id"`
+	Namespace         string                  `yaml:"namespace,omitempty"`
+	Labels            map[string]string       `yaml:"labels,omitempty"`
+	IngressDomains    []string                `yaml:"ingress-domains,omitempty"`
+	Policies          []PolicyDescription     `yaml:"policies,omitempty"`
+	LatestReleasePlan *ReleasePlanDescription `yaml:"latest-release-plan,omitempty"`
+	Errors            []string                `yaml:"errors,omitempty"`
+}
+
+// PolicyDescription - policy referenced by a service config slot
+type PolicyDescription struct {
+	Slot    string `yaml:"slot"`
+	ID      uint64 `yaml:"id"`
+	Name    string `yaml:"name,omitempty"`
+	Type    string `yaml:"type,omitempty"`
+	Missing bool   `yaml:"missing,omitempty"`
+}
+
+// ReleasePlanDescription - status of the release plan of the latest service version
+type ReleasePlanDescription struct {
+	Version string `yaml:"version"`
+	Status  string `yaml:"status"`
+}
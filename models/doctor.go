@@ -0,0 +1,27 @@
+package models
+
+import "fmt"
+
+const (
+	// ClusterWithoutConfigIssue - cluster directory without Release Agent config
+	ClusterWithoutConfigIssue = "cluster without config"
+	// OrphanedServiceConfigIssue - service config of an application not mapped to any namespace
+	OrphanedServiceConfigIssue = "orphaned service config"
+	// OrphanedReleasePlanIssue - release plan of a service without service config or of an unmapped application
+	OrphanedReleasePlanIssue = "orphaned release plan"
+	// InvalidServiceConfigIssue - service config which does not pass validation
+	InvalidServiceConfigIssue = "invalid service config"
+)
+
+// ProjectIssue - consistency problem found in the key value store of a project
+// fixable issues are fixed by deleting the key, or all keys below it in case of a cluster without config
+type ProjectIssue struct {
+	Kind    string `yaml:"kind"`
+	Key     string `yaml:"key"`
+	Message string `yaml:"message"`
+	Fixable bool   `yaml:"fixable"`
+}
+
+func (issue ProjectIssue) String() string {
+	return fmt.Sprintf("%s: %s: %s", issue.Kind, issue.Key, issue.Message)
+}
@@ -25,6 +25,9 @@ func NewValidateDTO() ValidateDTO {
 		}
 		return name
 	})
+	for tag, validation := range k8sValidators {
+		validate.RegisterValidation(tag, validation)
+	}
 
 	return func(obj interface{}) error {
 		if kindOfData(obj) == reflect.Struct {
@@ -85,6 +88,18 @@ func getSingleValidationErrorMessage(err validator.FieldError) string {
 		default:
 			return fmt.Sprintf("%s field must be at least %s", trimmedNamespace, err.Param())
 		}
+	case "k8s_dns1123_label":
+		return fmt.Sprintf("%s field must be a lowercase RFC 1123 label of at most 63 characters, consisting of alphanumeric characters or '-' and starting and ending with an alphanumeric character", trimmedNamespace)
+	case "k8s_dns1123_subdomain":
+		return fmt.Sprintf("%s field must be a lowercase RFC 1123 subdomain of at most 253 characters, consisting of labels of alphanumeric characters or '-' separated by '.'", trimmedNamespace)
+	case "k8s_label_key":
+		return fmt.Sprintf("%s field must be a label key: an optional DNS subdomain prefix and '/' followed by a name of at most 63 alphanumeric characters, '-', '_' or '.' starting and ending with an alphanumeric character", trimmedNamespace)
+	case "k8s_label_value":
+		return fmt.Sprintf("%s field must be a label value: empty or at most 63 alphanumeric characters, '-', '_' or '.' starting and ending with an alphanumeric character", trimmedNamespace)
+	case "k8s_ingress_host":
+		return fmt.Sprintf("%s field must be a lowercase RFC 1123 hostname, optionally starting with a '*.' wildcard", trimmedNamespace)
+	case "k8s_ingress_path":
+		return fmt.Sprintf("%s field must be an absolute path starting with '/' without whitespace, empty, '.' or '..' segments", trimmedNamespace)
 	}
 	return fmt.Sprintf("%s field is not valid", trimmedNamespace)
 }
@@ -91,6 +91,108 @@ func TestServiceConfigValidation(t *testing.T) {
 					build()},
 			want: errors.New("ingress_rules[0].port field is required"),
 		},
+		{
+			name: "service config with Kubernetes namespace which is not a DNS label",
+			args: args{validServiceConfig().withK8SNamespace("Test_Namespace").build()},
+			want: errors.New("k8s_namespace field must be a lowercase RFC 1123 label of at most 63 characters, consisting of alphanumeric characters or '-' and starting and ending with an alphanumeric character"),
+		},
+		{
+			name: "service config with prefixed Kubernetes label key and empty value",
+			args: args{validServiceConfig().withK8SLabels(map[string]string{"example.com/team": "", "app": "shop.v1_a"}).build()},
+			want: nil,
+		},
+		{
+			name: "service config with invalid Kubernetes label key",
+			args: args{validServiceConfig().withK8SLabels(map[string]string{"-app": "shop"}).build()},
+			want: errors.New("k8s_labels[-app] field must be a label key: an optional DNS subdomain prefix and '/' followed by a name of at most 63 alphanumeric characters, '-', '_' or '.' starting and ending with an alphanumeric character"),
+		},
+		{
+			name: "service config with invalid Kubernetes label value",
+			args: args{validServiceConfig().withK8SLabels(map[string]string{"app": "shop web"}).build()},
+			want: errors.New("k8s_labels[app] field must be a label value: empty or at most 63 alphanumeric characters, '-', '_' or '.' starting and ending with an alphanumeric character"),
+		},
+		{
+			name: "service config with version selector which is not a label key",
+			args: args{validServiceConfig().withVersionSelector("Example.com/version").build()},
+			want: errors.New("version_selector field must be a label key: an optional DNS subdomain prefix and '/' followed by a name of at most 63 alphanumeric characters, '-', '_' or '.' starting and ending with an alphanumeric character"),
+		},
+		{
+			name: "service config with ingress rule with wildcard domain",
+			args: args{
+				validServiceConfig().
+					withIngressRules(
+						[]*models.ServiceConfigIngressRule{
+							validIngressRule().withDomain("*.test.local").build(),
+						}).
+					build()},
+			want: nil,
+		},
+		{
+			name: "service config with ingress rule with wildcard inside domain",
+			args: args{
+				validServiceConfig().
+					withIngressRules(
+						[]*models.ServiceConfigIngressRule{
+							validIngressRule().withDomain("api.*.local").build(),
+						}).
+					build()},
+			want: errors.New("ingress_rules[0].domain field must be a lowercase RFC 1123 hostname, optionally starting with a '*.' wildcard"),
+		},
+		{
+			name: "service config with ingress rule with invalid TLS secret name",
+			args: args{
+				validServiceConfig().
+					withIngressRules(
+						[]*models.ServiceConfigIngressRule{
+							validIngressRule().withTLSSecretName("tls_secret").build(),
+						}).
+					build()},
+			want: errors.New("ingress_rules[0].tls_secret_name field must be a lowercase RFC 1123 subdomain of at most 253 characters, consisting of labels of alphanumeric characters or '-' separated by '.'"),
+		},
+		{
+			name: "service config with ingress rule with relative path",
+			args: args{
+				validServiceConfig().
+					withIngressRules(
+						[]*models.ServiceConfigIngressRule{
+							validIngressRule().withPath("api").build(),
+						}).
+					build()},
+			want: errors.New("ingress_rules[0].path field must be an absolute path starting with '/' without whitespace, empty, '.' or '..' segments"),
+		},
+		{
+			name: "service config with ingress rule with parent path segment",
+			args: args{
+				validServiceConfig().
+					withIngressRules(
+						[]*models.ServiceConfigIngressRule{
+							validIngressRule().withPath("/api/../admin").build(),
+						}).
+					build()},
+			want: errors.New("ingress_rules[0].path field must be an absolute path starting with '/' without whitespace, empty, '.' or '..' segments"),
+		},
+		{
+			name: "service config with ingress rule with port out of range",
+			args: args{
+				validServiceConfig().
+					withIngressRules(
+						[]*models.ServiceConfigIngressRule{
+							validIngressRule().withPort(int64ToPointer(65536)).build(),
+						}).
+					build()},
+			want: errors.New("ingress_rules[0].port field must be at most 65535"),
+		},
+		{
+			name: "service config with ingress rule with zero port",
+			args: args{
+				validServiceConfig().
+					withIngressRules(
+						[]*models.ServiceConfigIngressRule{
+							validIngressRule().withPort(int64ToPointer(0)).build(),
+						}).
+					build()},
+			want: errors.New("ingress_rules[0].port field must be at least 1"),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -135,6 +237,26 @@ func (builder *serviceConfigBuilder) withVersionSelector(versionSelector string)
 	return builder
 }
 
+func (builder *serviceConfigBuilder) withDefaultPolicyID(policyID *uint64) *serviceConfigBuilder {
+	builder.serviceConfig.DefaultPolicyID = policyID
+	return builder
+}
+
+func (builder *serviceConfigBuilder) withDefaultPolicyName(policyName string) *serviceConfigBuilder {
+	builder.serviceConfig.DefaultPolicyName = policyName
+	return builder
+}
+
+func (builder *serviceConfigBuilder) withPatchPolicyID(policyID *uint64) *serviceConfigBuilder {
+	builder.serviceConfig.PatchPolicyID = policyID
+	return builder
+}
+
+func (builder *serviceConfigBuilder) withValidationPolicyIDs(policyIDs ...uint64) *serviceConfigBuilder {
+	builder.serviceConfig.ValidationPolicyIDs = policyIDs
+	return builder
+}
+
 func (builder *serviceConfigBuilder) withIngressRules(ingressRules []*models.ServiceConfigIngressRule) *serviceConfigBuilder {
 	builder.serviceConfig.IngressRules = ingressRules
 	return builder
@@ -174,6 +296,11 @@ func (builder *ingressRuleBuilder) withDomain(domain string) *ingressRuleBuilder
 	return builder
 }
 
+func (builder *ingressRuleBuilder) withTLSSecretName(tlsSecretName string) *ingressRuleBuilder {
+	builder.ingressRule.TLSSecretName = tlsSecretName
+	return builder
+}
+
 func (builder *ingressRuleBuilder) withPath(path string) *ingressRuleBuilder {
 	builder.ingressRule.Path = path
 	return builder
@@ -0,0 +1,118 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// IngressClaim - domain and path of an ingress rule claimed by a service
+type IngressClaim struct {
+	ApplicationID uint64
+	ServiceID     uint64
+	Domain        string
+	Path          string
+}
+
+// IngressConflict - domain and path claimed by more than one service of a cluster
+type IngressConflict struct {
+	Domain string
+	Path   string
+	Claims []IngressClaim
+}
+
+func (c IngressConflict) String() string {
+	services := make([]string, len(c.Claims))
+	for i, claim := range c.Claims {
+		services[i] = fmt.Sprintf("service '%d' of application '%d'", claim.ServiceID, claim.ApplicationID)
+	}
+	return fmt.Sprintf("ingress domain '%s' with path '%s' is claimed by %s", c.Domain, c.Path, strings.Join(services, ", "))
+}
+
+// Involves - checks whether service is one of the conflicting services
+func (c IngressConflict) Involves(applicationID, serviceID uint64) bool {
+	for _, claim := range c.Claims {
+		if claim.ApplicationID == applicationID && claim.ServiceID == serviceID {
+			return true
+		}
+	}
+	return false
+}
+
+// GetIngressClaims - gets domains and paths of ingress rules of a service config
+// domains are compared case insensitively and trailing slashes of paths are ignored
+func GetIngressClaims(applicationID, serviceID uint64, serviceConfig ServiceConfig) []IngressClaim {
+	claims := make([]IngressClaim, 0, len(serviceConfig.IngressRules))
+	for _, ingressRule := range serviceConfig.IngressRules {
+		if ingressRule == nil {
+			continue
+		}
+		claims = append(claims, IngressClaim{
+			ApplicationID: applicationID,
+			ServiceID:     serviceID,
+			Domain:        normalizeIngressDomain(ingressRule.Domain),
+			Path:          normalizeIngressPath(ingressRule.Path),
+		})
+	}
+	return claims
+}
+
+// FindIngressConflicts - finds domain and path pairs claimed by more than one service
+// a service repeating the same rule does not conflict with itself, conflicts are sorted by domain and path
+func FindIngressConflicts(claims []IngressClaim) []IngressConflict {
+	type ingressKey struct {
+		domain string
+		path   string
+	}
+	claimsByKey := make(map[ingressKey][]IngressClaim)
+	for _, claim := range claims {
+		key := ingressKey{domain: claim.Domain, path: claim.Path}
+		duplicate := false
+		for _, existing := range claimsByKey[key] {
+			if existing.ApplicationID == claim.ApplicationID && existing.ServiceID == claim.ServiceID {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			claimsByKey[key] = append(claimsByKey[key], claim)
+		}
+	}
+
+	conflicts := make([]IngressConflict, 0)
+	for key, keyClaims := range claimsByKey {
+		if len(keyClaims) < 2 {
+			continue
+		}
+		sort.Slice(keyClaims, func(i, j int) bool {
+			if keyClaims[i].ApplicationID != keyClaims[j].ApplicationID {
+				return keyClaims[i].ApplicationID < keyClaims[j].ApplicationID
+			}
+			return keyClaims[i].ServiceID < keyClaims[j].ServiceID
+		})
+		conflicts = append(conflicts, IngressConflict{
+			Domain: key.domain,
+			Path:   key.path,
+			Claims: keyClaims,
+		})
+	}
+	sort.Slice(conflicts, func(i, j int) bool {
+		if conflicts[i].Domain != conflicts[j].Domain {
+			return conflicts[i].Domain < conflicts[j].Domain
+		}
+		return conflicts[i].Path < conflicts[j].Path
+	})
+	return conflicts
+}
+
+func normalizeIngressDomain(domain string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(domain)), ".")
+}
+
+func normalizeIngressPath(path string) string {
+	path = strings.TrimSpace(path)
+	if trimmed := strings.TrimRight(path, "/"); trimmed != "" {
+		return trimmed
+	}
+	return "/"
+}
@@ -0,0 +1,78 @@
+package models_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/magneticio/forklift/models"
+)
+
+func TestFindIngressConflicts(t *testing.T) {
+	newServiceConfig := func(rules ...[2]string) models.ServiceConfig {
+		serviceConfig := models.ServiceConfig{}
+		for _, rule := range rules {
+			serviceConfig.IngressRules = append(serviceConfig.IngressRules, &models.ServiceConfigIngressRule{Domain: rule[0], Path: rule[1]})
+		}
+		return serviceConfig
+	}
+
+	claims := make([]models.IngressClaim, 0)
+	claims = append(claims, models.GetIngressClaims(1, 10, newServiceConfig([2]string{"shop.example.com", "/"}, [2]string{"shop.example.com", "/"}))...)
+	claims = append(claims, models.GetIngressClaims(1, 11, newServiceConfig([2]string{"Shop.Example.com", "/api/"}))...)
+	claims = append(claims, models.GetIngressClaims(2, 20, newServiceConfig([2]string{"shop.example.com", "/api"}, [2]string{"shop.example.com", "/admin"}))...)
+	claims = append(claims, models.GetIngressClaims(3, 30, newServiceConfig([2]string{"shop.example.com.", "/"}))...)
+
+	want := []models.IngressConflict{
+		{
+			Domain: "shop.example.com",
+			Path:   "/",
+			Claims: []models.IngressClaim{
+				{ApplicationID: 1, ServiceID: 10, Domain: "shop.example.com", Path: "/"},
+				{ApplicationID: 3, ServiceID: 30, Domain: "shop.example.com", Path: "/"},
+			},
+		},
+		{
+			Domain: "shop.example.com",
+			Path:   "/api",
+			Claims: []models.IngressClaim{
+				{ApplicationID: 1, ServiceID: 11, Domain: "shop.example.com", Path: "/api"},
+				{ApplicationID: 2, ServiceID: 20, Domain: "shop.example.com", Path: "/api"},
+			},
+		},
+	}
+	got := models.FindIngressConflicts(claims)
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("FindIngressConflicts() = %v, want %v", got, want)
+	}
+
+	if !got[0].Involves(3, 30) || got[0].Involves(2, 20) {
+		t.Errorf("Involves() of %v is wrong", got[0])
+	}
+	wantText := "ingress domain 'shop.example.com' with path '/api' is claimed by service '11' of application '1', service '20' of application '2'"
+	if got[1].String() != wantText {
+		t.Errorf("String() = %q, want %q", got[1].String(), wantText)
+	}
+}
+
+func TestFindIngressConflictsNormalizesDomains(t *testing.T) {
+	newServiceConfig := func(domain string) models.ServiceConfig {
+		return models.ServiceConfig{
+			IngressRules: []*models.ServiceConfigIngressRule{{Domain: domain, Path: "/"}},
+		}
+	}
+
+	claims := make([]models.IngressClaim, 0)
+	claims = append(claims, models.GetIngressClaims(1, 10, newServiceConfig("TEST.Local."))...)
+	claims = append(claims, models.GetIngressClaims(2, 20, newServiceConfig("test.local"))...)
+	claims = append(claims, models.GetIngressClaims(3, 30, newServiceConfig("Test.LOCAL"))...)
+
+	conflicts := models.FindIngressConflicts(claims)
+	if len(conflicts) != 1 || conflicts[0].Domain != "test.local" || len(conflicts[0].Claims) != 3 {
+		t.Fatalf("FindIngressConflicts() = %v, want one conflict of all three services on test.local", conflicts)
+	}
+	for _, claim := range conflicts[0].Claims {
+		if claim.Domain != "test.local" {
+			t.Errorf("claim of service '%d' has domain %q, want test.local", claim.ServiceID, claim.Domain)
+		}
+	}
+}
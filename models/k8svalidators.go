@@ -0,0 +1,92 @@
+package models
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	dns1123LabelMaxLength     = 63
+	dns1123SubdomainMaxLength = 253
+	labelNameMaxLength        = 63
+)
+
+var dns1123LabelPattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+var labelNamePattern = regexp.MustCompile(`^[A-Za-z0-9]([-A-Za-z0-9_.]*[A-Za-z0-9])?$`)
+
+var ingressPathPattern = regexp.MustCompile(`^/[^\s]*$`)
+
+// k8sValidators - custom validation tags following Kubernetes apimachinery rules
+var k8sValidators = map[string]validator.Func{
+	"k8s_dns1123_label": func(fl validator.FieldLevel) bool {
+		return isDNS1123Label(fl.Field().String())
+	},
+	"k8s_dns1123_subdomain": func(fl validator.FieldLevel) bool {
+		return isDNS1123Subdomain(fl.Field().String())
+	},
+	"k8s_label_key": func(fl validator.FieldLevel) bool {
+		return isLabelKey(fl.Field().String())
+	},
+	"k8s_label_value": func(fl validator.FieldLevel) bool {
+		return isLabelValue(fl.Field().String())
+	},
+	"k8s_ingress_host": func(fl validator.FieldLevel) bool {
+		return isIngressHost(fl.Field().String())
+	},
+	"k8s_ingress_path": func(fl validator.FieldLevel) bool {
+		return isIngressPath(fl.Field().String())
+	},
+}
+
+func isDNS1123Label(value string) bool {
+	return len(value) <= dns1123LabelMaxLength && dns1123LabelPattern.MatchString(value)
+}
+
+func isDNS1123Subdomain(value string) bool {
+	if len(value) > dns1123SubdomainMaxLength {
+		return false
+	}
+	for _, label := range strings.Split(value, ".") {
+		if !isDNS1123Label(label) {
+			return false
+		}
+	}
+	return true
+}
+
+// isLabelKey - label key is a name with an optional DNS subdomain prefix like example.com/name
+func isLabelKey(value string) bool {
+	name := value
+	if separator := strings.Index(value, "/"); separator >= 0 {
+		if !isDNS1123Subdomain(value[:separator]) {
+			return false
+		}
+		name = value[separator+1:]
+	}
+	return len(name) <= labelNameMaxLength && labelNamePattern.MatchString(name)
+}
+
+func isLabelValue(value string) bool {
+	return value == "" || (len(value) <= labelNameMaxLength && labelNamePattern.MatchString(value))
+}
+
+// isIngressHost - host is a DNS subdomain which can start with a single wildcard label like *.example.com
+func isIngressHost(value string) bool {
+	return isDNS1123Subdomain(strings.TrimPrefix(value, "*."))
+}
+
+// isIngressPath - path is absolute and does not contain empty, . or .. segments
+func isIngressPath(value string) bool {
+	if !ingressPathPattern.MatchString(value) {
+		return false
+	}
+	for _, invalid := range []string{"//", "/./", "/../", "%2f", "%2F"} {
+		if strings.Contains(value, invalid) {
+			return false
+		}
+	}
+	return !strings.HasSuffix(value, "/..") && !strings.HasSuffix(value, "/.")
+}
@@ -0,0 +1,91 @@
+package models
+
+import (
+	"path"
+	"sort"
+	"strings"
+)
+
+// KeyTree - keys of the key value store below a root path
+// types of keys and directories, if known, are shown next to their names
+type KeyTree struct {
+	Root  string
+	Keys  []string
+	Types map[string]string
+}
+
+type keyTreeNode map[string]keyTreeNode
+
+// String - renders keys as a tree below the root path
+func (kt KeyTree) String() string {
+	root := make(keyTreeNode)
+	for _, key := range kt.Keys {
+		node := root
+		for _, segment := range strings.Split(strings.Trim(key, "/"), "/") {
+			if node[segment] == nil {
+				node[segment] = make(keyTreeNode)
+			}
+			node = node[segment]
+		}
+	}
+	var sb strings.Builder
+	sb.WriteString(kt.Root + "\n")
+	kt.writeNode(&sb, root, "", "")
+	return sb.String()
+}
+
+func (kt KeyTree) writeNode(sb *strings.Builder, node keyTreeNode, nodePath string, indent string) {
+	names := make([]string, 0, len(node))
+	for name := range node {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for i, name := range names {
+		branch, childIndent := "├── ", "│   "
+		if i == len(names)-1 {
+			branch, childIndent = "└── ", "    "
+		}
+		childPath := path.Join(nodePath, name)
+		sb.WriteString(indent + branch + name)
+		if keyType := kt.Types[childPath]; keyType != "" {
+			sb.WriteString(" (" + keyType + ")")
+		}
+		sb.WriteString("\n")
+		kt.writeNode(sb, node[name], childPath, indent+childIndent)
+	}
+}
+
+// GetProjectKeyType - gets resource type of a key or directory from its path relative to the project path
+func GetProjectKeyType(relativePath string, isKey bool) string {
+	segments := strings.Split(strings.Trim(relativePath, "/"), "/")
+	switch segments[0] {
+	case "clusters":
+		switch {
+		case len(segments) == 2:
+			return "cluster"
+		case len(segments) == 3 && segments[2] == "release-agent-config":
+			return "release agent config"
+		case len(segments) < 4 || segments[2] != "applications":
+			return ""
+		case len(segments) == 4:
+			return "application"
+		case len(segments) == 6 && segments[4] == "service-configs":
+			return "service config"
+		case len(segments) == 6 && segments[4] == "release-plans":
+			return "service"
+		case len(segments) == 7 && segments[4] == "release-plans":
+			return "release plan"
+		}
+	case "policies":
+		if isKey {
+			return "policy"
+		}
+	case "policy-names":
+		return "policy names"
+	case "secrets":
+		if isKey {
+			return "secret"
+		}
+	}
+	return ""
+}
@@ -0,0 +1,70 @@
+package models_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/magneticio/forklift/models"
+)
+
+func TestKeyTreeString(t *testing.T) {
+	keyTree := models.KeyTree{
+		Root: "vamp/projects/1/clusters/4321",
+		Keys: []string{
+			"release-agent-config",
+			"applications/12345/service-configs/4555",
+			"applications/12345/release-plans/4555/1.0.0",
+			"applications/12345/release-plans/4555/1.0.1",
+		},
+	}
+	want := `vamp/projects/1/clusters/4321
+├── applications
+│   └── 12345
+│       ├── release-plans
+│       │   └── 4555
+│       │       ├── 1.0.0
+│       │       └── 1.0.1
+│       └── service-configs
+│           └── 4555
+└── release-agent-config
+`
+	if got := keyTree.String(); got != want {
+		t.Errorf("String() = %v, want %v", got, want)
+	}
+}
+
+func TestKeyTreeStringWithTypes(t *testing.T) {
+	keys := []string{
+		"clusters/4321/release-agent-config",
+		"clusters/4321/applications/12345/release-plans/4555/1.0.0",
+		"secrets/hooks/slack",
+	}
+	keyTree := models.KeyTree{
+		Root:  "vamp/projects/1",
+		Keys:  keys,
+		Types: make(map[string]string),
+	}
+	for _, key := range keys {
+		segments := strings.Split(key, "/")
+		for i := range segments {
+			nodePath := strings.Join(segments[:i+1], "/")
+			keyTree.Types[nodePath] = models.GetProjectKeyType(nodePath, i == len(segments)-1)
+		}
+	}
+	want := `vamp/projects/1
+├── clusters
+│   └── 4321 (cluster)
+│       ├── applications
+│       │   └── 12345 (application)
+│       │       └── release-plans
+│       │           └── 4555 (service)
+│       │               └── 1.0.0 (release plan)
+│       └── release-agent-config (release agent config)
+└── secrets
+    └── hooks
+        └── slack (secret)
+`
+	if got := keyTree.String(); got != want {
+		t.Errorf("String() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,143 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	labelEquals       = "="
+	labelNotEquals    = "!="
+	labelExists       = "exists"
+	labelDoesNotExist = "!exists"
+
+	// ServiceSortByID - services are sorted by id
+	ServiceSortByID = "id"
+	// ServiceSortByNamespace - services are sorted by namespace and then by id
+	ServiceSortByNamespace = "namespace"
+	// ServiceSortByVersionSelector - services are sorted by version selector and then by id
+	ServiceSortByVersionSelector = "version-selector"
+)
+
+// ServiceSortKeys - keys services can be sorted by
+var ServiceSortKeys = []string{ServiceSortByID, ServiceSortByNamespace, ServiceSortByVersionSelector}
+
+var labelKeyPattern = regexp.MustCompile(`^([A-Za-z0-9][-A-Za-z0-9_.]*/)?[A-Za-z0-9]([-A-Za-z0-9_.]*[A-Za-z0-9])?$`)
+
+type labelRequirement struct {
+	key      string
+	operator string
+	value    string
+}
+
+// LabelSelector - Kubernetes style equality based label selector like app=nginx,tier!=cache,canary,!legacy
+type LabelSelector []labelRequirement
+
+// ParseLabelSelector - parses comma separated requirements, an empty selector matches everything
+func ParseLabelSelector(text string) (LabelSelector, error) {
+	selector := make(LabelSelector, 0)
+	if strings.TrimSpace(text) == "" {
+		return selector, nil
+	}
+	for _, requirementText := range strings.Split(text, ",") {
+		requirementText = strings.TrimSpace(requirementText)
+		var requirement labelRequirement
+		switch {
+		case strings.Contains(requirementText, "!="):
+			parts := strings.SplitN(requirementText, "!=", 2)
+			requirement = labelRequirement{key: parts[0], operator: labelNotEquals, value: parts[1]}
+		case strings.Contains(requirementText, "=="):
+			parts := strings.SplitN(requirementText, "==", 2)
+			requirement = labelRequirement{key: parts[0], operator: labelEquals, value: parts[1]}
+		case strings.Contains(requirementText, "="):
+			parts := strings.SplitN(requirementText, "=", 2)
+			requirement = labelRequirement{key: parts[0], operator: labelEquals, value: parts[1]}
+		case strings.HasPrefix(requirementText, "!"):
+			requirement = labelRequirement{key: strings.TrimPrefix(requirementText, "!"), operator: labelDoesNotExist}
+		default:
+			requirement = labelRequirement{key: requirementText, operator: labelExists}
+		}
+		requirement.key = strings.TrimSpace(requirement.key)
+		requirement.value = strings.TrimSpace(requirement.value)
+		if !labelKeyPattern.MatchString(requirement.key) {
+			return nil, fmt.Errorf("invalid label selector requirement '%s'", requirementText)
+		}
+		selector = append(selector, requirement)
+	}
+	return selector, nil
+}
+
+// Matches - checks that labels satisfy all requirements of the selector
+func (ls LabelSelector) Matches(labels map[string]string) bool {
+	for _, requirement := range ls {
+		value, exists := labels[requirement.key]
+		switch requirement.operator {
+		case labelEquals:
+			if !exists || value != requirement.value {
+				return false
+			}
+		case labelNotEquals:
+			if exists && value == requirement.value {
+				return false
+			}
+		case labelExists:
+			if !exists {
+				return false
+			}
+		case labelDoesNotExist:
+			if exists {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// FilterServiceViews - keeps services whose labels match the selector
+// services which cannot be read are always kept because their labels are unknown
+func FilterServiceViews(services []ServiceView, selector LabelSelector) []ServiceView {
+	filtered := make([]ServiceView, 0, len(services))
+	for _, service := range services {
+		if service.Error != "" || selector.Matches(service.Labels) {
+			filtered = append(filtered, service)
+		}
+	}
+	return filtered
+}
+
+// CheckServiceSortKey - checks that services can be sorted by the key
+func CheckServiceSortKey(sortBy string) error {
+	for _, key := range ServiceSortKeys {
+		if sortBy == key {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown sort key '%s', expected one of %v", sortBy, ServiceSortKeys)
+}
+
+// SortServiceViews - sorts services by one of ServiceSortKeys, ties are broken by id
+func SortServiceViews(services []ServiceView, sortBy string, reverse bool) error {
+	if err := CheckServiceSortKey(sortBy); err != nil {
+		return err
+	}
+	key := func(service ServiceView) string { return "" }
+	switch sortBy {
+	case ServiceSortByNamespace:
+		key = func(service ServiceView) string { return service.Namespace }
+	case ServiceSortByVersionSelector:
+		key = func(service ServiceView) string { return service.VersionSelector }
+	}
+	sort.SliceStable(services, func(i, j int) bool {
+		a, b := services[i], services[j]
+		if reverse {
+			a, b = b, a
+		}
+		if key(a) != key(b) {
+			return key(a) < key(b)
+		}
+		return a.ID < b.ID
+	})
+	return nil
+}
@@ -0,0 +1,83 @@
+package models_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/magneticio/forklift/models"
+)
+
+func TestLabelSelectorMatches(t *testing.T) {
+	labels := map[string]string{"app": "nginx", "tier": "web", "canary": ""}
+	tests := []struct {
+		selector string
+		want     bool
+	}{
+		{"", true},
+		{"app=nginx", true},
+		{"app==nginx,tier=web", true},
+		{"app=nginx,tier=cache", false},
+		{"tier!=cache", true},
+		{"tier!=web", false},
+		{"canary", true},
+		{"legacy", false},
+		{"!legacy", true},
+		{"!canary", false},
+		{"example.com/team!=payments", true},
+	}
+	for _, test := range tests {
+		selector, err := models.ParseLabelSelector(test.selector)
+		if err != nil {
+			t.Fatalf("ParseLabelSelector(%q) error = %v", test.selector, err)
+		}
+		if got := selector.Matches(labels); got != test.want {
+			t.Errorf("ParseLabelSelector(%q).Matches() = %v, want %v", test.selector, got, test.want)
+		}
+	}
+	for _, invalid := range []string{"app=nginx,", "=nginx", "app name=nginx"} {
+		if _, err := models.ParseLabelSelector(invalid); err == nil {
+			t.Errorf("ParseLabelSelector(%q) should fail", invalid)
+		}
+	}
+}
+
+func TestSortServiceViews(t *testing.T) {
+	services := []models.ServiceView{
+		{ID: 3, Namespace: "b"},
+		{ID: 1, Namespace: "b"},
+		{ID: 2, Namespace: "a"},
+	}
+	if err := models.SortServiceViews(services, models.ServiceSortByNamespace, false); err != nil {
+		t.Fatalf("SortServiceViews() error = %v", err)
+	}
+	ids := []uint64{services[0].ID, services[1].ID, services[2].ID}
+	if want := []uint64{2, 1, 3}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("SortServiceViews() by namespace = %v, want %v", ids, want)
+	}
+	if err := models.SortServiceViews(services, models.ServiceSortByID, true); err != nil {
+		t.Fatalf("SortServiceViews() error = %v", err)
+	}
+	ids = []uint64{services[0].ID, services[1].ID, services[2].ID}
+	if want := []uint64{3, 2, 1}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("SortServiceViews() by id reversed = %v, want %v", ids, want)
+	}
+	if err := models.SortServiceViews(services, "labels", false); err == nil {
+		t.Errorf("SortServiceViews() with unknown key should fail")
+	}
+}
+
+func TestFilterServiceViewsKeepsBrokenServices(t *testing.T) {
+	services := []models.ServiceView{
+		{ID: 1, Labels: map[string]string{"app": "nginx"}},
+		{ID: 2, Labels: map[string]string{"app": "redis"}},
+		{ID: 3, Error: "cannot get service config: connection reset"},
+	}
+	selector, err := models.ParseLabelSelector("app=nginx")
+	if err != nil {
+		t.Fatalf("ParseLabelSelector() error = %v", err)
+	}
+	filtered := models.FilterServiceViews(services, selector)
+	if len(filtered) != 2 || filtered[0].ID != 1 || filtered[1].ID != 3 {
+		t.Errorf("FilterServiceViews() = %v, want services 1 and 3", filtered)
+	}
+}
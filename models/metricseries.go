@@ -0,0 +1,88 @@
+package models
+
+import (
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MetricSeries - values of metrics recorded at points in time relative to the release start
+type MetricSeries struct {
+	Times  []time.Duration
+	Values map[string][]*float64
+}
+
+// ParseMetricSeries - parses CSV text with a header row "time,<metric>,<metric>..."
+// time is a duration like 90s or 1m30s or a number of seconds, empty cells mean there is no value
+func ParseMetricSeries(seriesText string) (*MetricSeries, error) {
+	reader := csv.NewReader(strings.NewReader(seriesText))
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("cannot read metric series: %v", err)
+	}
+	if len(records) < 2 {
+		return nil, fmt.Errorf("metric series must contain a header row and at least one row of values")
+	}
+
+	header := records[0]
+	if len(header) < 2 || strings.TrimSpace(header[0]) != "time" {
+		return nil, fmt.Errorf("metric series header must start with 'time' column followed by metric names")
+	}
+
+	type row struct {
+		time   time.Duration
+		values []*float64
+	}
+	rows := make([]row, 0, len(records)-1)
+	for i, record := range records[1:] {
+		line := i + 2
+		recordTime, err := parseSeriesTime(strings.TrimSpace(record[0]))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid time '%s'", line, record[0])
+		}
+		values := make([]*float64, len(header)-1)
+		for j, cell := range record[1:] {
+			cell = strings.TrimSpace(cell)
+			if cell == "" {
+				continue
+			}
+			value, err := strconv.ParseFloat(cell, 64)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid value '%s' of metric '%s'", line, cell, header[j+1])
+			}
+			values[j] = &value
+		}
+		rows = append(rows, row{time: recordTime, values: values})
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i].time < rows[j].time
+	})
+
+	series := &MetricSeries{
+		Times:  make([]time.Duration, len(rows)),
+		Values: make(map[string][]*float64, len(header)-1),
+	}
+	for j, metric := range header[1:] {
+		metricValues := make([]*float64, len(rows))
+		for i, row := range rows {
+			metricValues[i] = row.values[j]
+		}
+		series.Values[strings.TrimSpace(metric)] = metricValues
+	}
+	for i, row := range rows {
+		series.Times[i] = row.time
+	}
+
+	return series, nil
+}
+
+func parseSeriesTime(timeText string) (time.Duration, error) {
+	if seconds, err := strconv.ParseFloat(timeText, 64); err == nil {
+		return time.Duration(seconds * float64(time.Second)), nil
+	}
+	return time.ParseDuration(timeText)
+}
@@ -2,6 +2,8 @@ package models
 
 import (
 	"fmt"
+	"regexp"
+	"sort"
 	"strconv"
 
 	"gopkg.in/yaml.v3"
@@ -98,42 +100,287 @@ type ReleaseAgentConfig struct {
 	ClusterName                 string            `json:"cluster_name"`
 }
 
+// GetApplications - groups namespaces of Release Agent config by application
+// applications are sorted by id and namespaces by name
+func (rac ReleaseAgentConfig) GetApplications() []ApplicationView {
+	namespacesByApplicationID := make(map[uint64][]string)
+	for namespace, applicationID := range rac.K8SNamespaceToApplicationID {
+		namespacesByApplicationID[applicationID] = append(namespacesByApplicationID[applicationID], namespace)
+	}
+	applications := make([]ApplicationView, 0, len(namespacesByApplicationID))
+	for applicationID, namespaces := range namespacesByApplicationID {
+		sort.Strings(namespaces)
+		applications = append(applications, NewApplicationView(applicationID, namespaces))
+	}
+	sort.Slice(applications, func(i, j int) bool {
+		return applications[i].ID < applications[j].ID
+	})
+	return applications
+}
+
+// GetApplicationNamespaces - gets sorted namespaces of an application
+func (rac ReleaseAgentConfig) GetApplicationNamespaces(applicationID uint64) []string {
+	namespaces := make([]string, 0)
+	for namespace, configApplicationID := range rac.K8SNamespaceToApplicationID {
+		if configApplicationID == applicationID {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+	sort.Strings(namespaces)
+	return namespaces
+}
+
 // ServiceConfig - service config for Release Agent
 type ServiceConfig struct {
-	ApplicationID   *uint64                     `json:"application_id" validate:"required"`
-	ServiceID       *uint64                     `json:"service_id" validate:"required"`
-	K8SNamespace    string                      `json:"k8s_namespace" validate:"required,min=1"`
-	K8sLabels       map[string]string           `json:"k8s_labels" validate:"required,min=1"`
-	VersionSelector string                      `json:"version_selector" validate:"required,min=1"`
-	DefaultPolicyID *uint64                     `json:"default_policy_id"`
-	PatchPolicyID   *uint64                     `json:"patch_policy_id"`
-	MinorPolicyID   *uint64                     `json:"minor_policy_id"`
-	MajorPolicyID   *uint64                     `json:"major_policy_id"`
-	IngressRules    []*ServiceConfigIngressRule `json:"ingress_rules" validate:"dive"`
-	IsHeadless      bool                        `json:"headless"`
+	ApplicationID       *uint64                     `json:"application_id" validate:"required"`
+	ServiceID           *uint64                     `json:"service_id" validate:"required"`
+	K8SNamespace        string                      `json:"k8s_namespace" validate:"required,k8s_dns1123_label"`
+	K8sLabels           map[string]string           `json:"k8s_labels" validate:"required,min=1,dive,keys,k8s_label_key,endkeys,k8s_label_value"`
+	VersionSelector     string                      `json:"version_selector" validate:"required,k8s_label_key"`
+	DefaultPolicyID     *uint64                     `json:"default_policy_id"`
+	PatchPolicyID       *uint64                     `json:"patch_policy_id"`
+	MinorPolicyID       *uint64                     `json:"minor_policy_id"`
+	MajorPolicyID       *uint64                     `json:"major_policy_id"`
+	DefaultPolicyName   string                      `json:"default_policy_name,omitempty"`
+	PatchPolicyName     string                      `json:"patch_policy_name,omitempty"`
+	MinorPolicyName     string                      `json:"minor_policy_name,omitempty"`
+	MajorPolicyName     string                      `json:"major_policy_name,omitempty"`
+	ValidationPolicyIDs []uint64                    `json:"validation_policy_ids,omitempty"`
+	IngressRules        []*ServiceConfigIngressRule `json:"ingress_rules" validate:"dive"`
+	IsHeadless          bool                        `json:"headless"`
 }
 
 // Validate - additional validation of ServiceConfig structure
 // that cannot be achieved using go-playgroud validator
 func (sc ServiceConfig) Validate() error {
-	if (sc.MajorPolicyID == nil || sc.MinorPolicyID == nil || sc.PatchPolicyID == nil) && sc.DefaultPolicyID == nil {
+	slots := []struct {
+		name       string
+		policyID   *uint64
+		policyName string
+	}{
+		{"default", sc.DefaultPolicyID, sc.DefaultPolicyName},
+		{"patch", sc.PatchPolicyID, sc.PatchPolicyName},
+		{"minor", sc.MinorPolicyID, sc.MinorPolicyName},
+		{"major", sc.MajorPolicyID, sc.MajorPolicyName},
+	}
+	defined := make(map[string]bool, len(slots))
+	for _, slot := range slots {
+		if slot.policyID != nil && slot.policyName != "" {
+			return fmt.Errorf("%s_policy_id and %s_policy_name cannot be both defined", slot.name, slot.name)
+		}
+		defined[slot.name] = slot.policyID != nil || slot.policyName != ""
+	}
+	if (!defined["major"] || !defined["minor"] || !defined["patch"]) && !defined["default"] {
 		return fmt.Errorf("DefaultPolicyID should be defined if any of other policies is not defined")
 	}
+	validationPolicyIDs := make(map[uint64]bool, len(sc.ValidationPolicyIDs))
+	for _, policyID := range sc.ValidationPolicyIDs {
+		if validationPolicyIDs[policyID] {
+			return fmt.Errorf("validation_policy_ids contains policy '%d' more than once", policyID)
+		}
+		validationPolicyIDs[policyID] = true
+	}
+	return nil
+}
+
+// ValidatePolicyName - checks that policy name consists of lower case alphanumeric characters or '-'
+// and starts and ends with an alphanumeric character
+func ValidatePolicyName(policyName string) error {
+	if len(policyName) > 63 || !policyNameRegexp.MatchString(policyName) {
+		return fmt.Errorf("policy name '%s' must consist of at most 63 lower case alphanumeric characters or '-' and start and end with an alphanumeric character", policyName)
+	}
+	if _, err := strconv.ParseUint(policyName, 10, 64); err == nil {
+		return fmt.Errorf("policy name '%s' must not be a number", policyName)
+	}
 	return nil
 }
 
+var policyNameRegexp = regexp.MustCompile("^[a-z0-9]([-a-z0-9]*[a-z0-9])?$")
+
+// PolicyReferences - policies referenced by service config slots, in order of precedence
+// followed by validation policies
+func (sc ServiceConfig) PolicyReferences() []ServiceConfigPolicyReference {
+	references := make([]ServiceConfigPolicyReference, 0)
+	for _, slot := range []struct {
+		name     string
+		policyID *uint64
+	}{
+		{"default", sc.DefaultPolicyID},
+		{"patch", sc.PatchPolicyID},
+		{"minor", sc.MinorPolicyID},
+		{"major", sc.MajorPolicyID},
+	} {
+		if slot.policyID != nil {
+			references = append(references, ServiceConfigPolicyReference{
+				Slot:     slot.name,
+				PolicyID: *slot.policyID,
+			})
+		}
+	}
+	for _, policyID := range sc.ValidationPolicyIDs {
+		references = append(references, ServiceConfigPolicyReference{
+			Slot:     ValidationPolicySlot,
+			PolicyID: policyID,
+		})
+	}
+	return references
+}
+
+// ValidationPolicySlot - slot of policies referenced by validation_policy_ids
+const ValidationPolicySlot = "validation"
+
+// ServiceConfigPolicyReference - policy referenced by a service config slot
+type ServiceConfigPolicyReference struct {
+	Slot     string
+	PolicyID uint64
+}
+
+// GetPolicySlotField - gets name of the service config field of a policy slot
+func GetPolicySlotField(slot string) string {
+	if slot == ValidationPolicySlot {
+		return "validation_policy_ids"
+	}
+	return slot + "_policy_id"
+}
+
+// PolicySelection - policy selected from service config for a version bump
+type PolicySelection struct {
+	PolicyID uint64
+	Slot     string
+	Explicit bool
+	Reason   string
+}
+
+// SelectPolicy - selects policy for a version bump
+// falling back to the default policy if there is no policy defined for the bump type
+func (sc ServiceConfig) SelectPolicy(bump VersionBump) (*PolicySelection, error) {
+	var policyID *uint64
+	switch bump {
+	case MajorVersionBump:
+		policyID = sc.MajorPolicyID
+	case MinorVersionBump:
+		policyID = sc.MinorPolicyID
+	case PatchVersionBump:
+		policyID = sc.PatchPolicyID
+	case InitialVersionBump:
+	default:
+		return nil, fmt.Errorf("unsupported version bump: '%s'", bump)
+	}
+	if policyID != nil {
+		return &PolicySelection{
+			PolicyID: *policyID,
+			Slot:     string(bump),
+			Explicit: true,
+			Reason:   fmt.Sprintf("%s_policy_id is defined in service config", bump),
+		}, nil
+	}
+	if sc.DefaultPolicyID != nil {
+		reason := fmt.Sprintf("%s_policy_id is not defined in service config, falling back to default_policy_id", bump)
+		if bump == InitialVersionBump {
+			reason = "there is no previous version, default_policy_id is used"
+		}
+		return &PolicySelection{
+			PolicyID: *sc.DefaultPolicyID,
+			Slot:     "default",
+			Reason:   reason,
+		}, nil
+	}
+	if bump == InitialVersionBump {
+		return nil, fmt.Errorf("there is no previous version and no default policy is configured, default_policy_id is required")
+	}
+	return nil, fmt.Errorf("no policy defined for %s version bump and no default policy", bump)
+}
+
+// GeneratedReleasePlan - release plan skeleton together with the policy selected for it
+type GeneratedReleasePlan struct {
+	ReleasePlan     ReleasePlan
+	PreviousVersion string
+	Bump            VersionBump
+	Policy          PolicySelection
+}
+
+// PolicyResolution - policy resolved for a service version bump
+type PolicyResolution struct {
+	FromVersion string
+	ToVersion   string
+	Bump        VersionBump
+	Policy      PolicySelection
+	PolicyText  string
+}
+
 // ServiceConfigIngressRule - service config ingress rule for Release Agent
 type ServiceConfigIngressRule struct {
-	Domain        string `json:"domain" validate:"required,min=4"`
-	TLSSecretName string `json:"tls_secret_name"`
-	Path          string `json:"path" validate:"required,min=1"`
-	Port          *int64 `json:"port" validate:"required"`
+	Domain        string `json:"domain" validate:"required,min=4,k8s_ingress_host"`
+	TLSSecretName string `json:"tls_secret_name" validate:"omitempty,k8s_dns1123_subdomain"`
+	Path          string `json:"path" validate:"required,k8s_ingress_path"`
+	Port          *int64 `json:"port" validate:"required,min=1,max=65535"`
+}
+
+// ServiceView - view used as an output for list command
+type ServiceView struct {
+	ID              uint64            `yaml:"id"`
+	Namespace       string            `yaml:"namespace"`
+	Labels          map[string]string `yaml:"labels"`
+	VersionSelector string            `yaml:"version-selector"`
+	Headless        bool              `yaml:"headless"`
+	IngressDomains  []string          `yaml:"ingress-domains,omitempty"`
+	PolicyIDs       []uint64          `yaml:"policy-ids,omitempty"`
+	Error           string            `yaml:"error,omitempty"`
+}
+
+// NewServiceView - creates view of a service config
+func NewServiceView(serviceID uint64, serviceConfig ServiceConfig) ServiceView {
+	view := ServiceView{
+		ID:              serviceID,
+		Namespace:       serviceConfig.K8SNamespace,
+		Labels:          serviceConfig.K8sLabels,
+		VersionSelector: serviceConfig.VersionSelector,
+		Headless:        serviceConfig.IsHeadless,
+	}
+	for _, ingressRule := range serviceConfig.IngressRules {
+		if ingressRule != nil {
+			view.IngressDomains = append(view.IngressDomains, ingressRule.Domain+ingressRule.Path)
+		}
+	}
+	seen := make(map[uint64]bool)
+	for _, reference := range serviceConfig.PolicyReferences() {
+		if !seen[reference.PolicyID] {
+			seen[reference.PolicyID] = true
+			view.PolicyIDs = append(view.PolicyIDs, reference.PolicyID)
+		}
+	}
+	return view
 }
 
 // ApplicationView - view used as an output for list and show commands
+// namespace is the first of the sorted namespaces and is kept for scripts reading single namespace applications
 type ApplicationView struct {
-	ID        uint64 `yaml:"id"`
-	Namespace string `yaml:"namespace"`
+	ID         uint64   `yaml:"id"`
+	Namespace  string   `yaml:"namespace"`
+	Namespaces []string `yaml:"namespaces"`
+}
+
+// NewApplicationView - creates application view from sorted namespaces of the application
+func NewApplicationView(applicationID uint64, namespaces []string) ApplicationView {
+	view := ApplicationView{
+		ID:         applicationID,
+		Namespaces: namespaces,
+	}
+	if len(namespaces) > 0 {
+		view.Namespace = namespaces[0]
+	}
+	return view
+}
+
+// DisplacedApplication - application whose namespaces have been reassigned to another application
+// service configs and release plans are listed only if the application has no namespace left
+type DisplacedApplication struct {
+	ID                    uint64
+	Namespaces            []string
+	Orphaned              bool
+	Path                  string
+	ServiceConfigIDs      []uint64
+	ReleasePlanServiceIDs []uint64
 }
 
 // ClusterView - view used as an output for list and show commands
@@ -145,9 +392,48 @@ type ClusterView struct {
 	OptimiserNatsChannel string `yaml:"optimiser-nats-channel"`
 }
 
+// PolicyReference - service config slot referencing a policy
+type PolicyReference struct {
+	ClusterID     uint64 `yaml:"cluster"`
+	ApplicationID uint64 `yaml:"application"`
+	ServiceID     uint64 `yaml:"service"`
+	Slot          string `yaml:"slot"`
+}
+
 // PolicyView - view used as an output for list command
 type PolicyView struct {
 	ID   uint64 `yaml:"id"`
 	Name string `yaml:"name,omitempty"`
 	Type string `yaml:"type"`
 }
+
+// ReleasePlanStatusNotStarted - status of a release plan, release group or environment that has not been started
+const ReleasePlanStatusNotStarted = "not started"
+
+// ReleasePlan - release plan for a service version
+type ReleasePlan struct {
+	Status        string                    `json:"status"`
+	Service       ReleasePlanService        `json:"service"`
+	ReleaseGroups []ReleasePlanReleaseGroup `json:"releaseGroups"`
+}
+
+// ReleasePlanService - service released by a release plan
+type ReleasePlanService struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// ReleasePlanReleaseGroup - group of environments released together
+type ReleasePlanReleaseGroup struct {
+	Group        int                      `json:"group"`
+	Name         string                   `json:"name"`
+	Status       string                   `json:"status"`
+	Environments []ReleasePlanEnvironment `json:"environments"`
+}
+
+// ReleasePlanEnvironment - environment of a release group
+type ReleasePlanEnvironment struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
@@ -0,0 +1,105 @@
+package models_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/magneticio/forklift/models"
+)
+
+func TestServiceConfigAdditionalValidation(t *testing.T) {
+	tests := []struct {
+		name          string
+		serviceConfig models.ServiceConfig
+		want          error
+	}{
+		{
+			name:          "valid service config",
+			serviceConfig: validServiceConfig().build(),
+			want:          nil,
+		},
+		{
+			name:          "service config with policy names instead of ids",
+			serviceConfig: validServiceConfig().withDefaultPolicyID(nil).withDefaultPolicyName("canary-standard").build(),
+			want:          nil,
+		},
+		{
+			name:          "service config without default policy",
+			serviceConfig: validServiceConfig().withDefaultPolicyID(nil).withPatchPolicyID(nil).build(),
+			want:          errors.New("DefaultPolicyID should be defined if any of other policies is not defined"),
+		},
+		{
+			name:          "service config with both policy id and name in the same slot",
+			serviceConfig: validServiceConfig().withDefaultPolicyName("canary-standard").build(),
+			want:          errors.New("default_policy_id and default_policy_name cannot be both defined"),
+		},
+		{
+			name:          "service config with validation policies",
+			serviceConfig: validServiceConfig().withValidationPolicyIDs(7, 8).build(),
+			want:          nil,
+		},
+		{
+			name:          "service config with duplicate validation policy",
+			serviceConfig: validServiceConfig().withValidationPolicyIDs(7, 7).build(),
+			want:          errors.New("validation_policy_ids contains policy '7' more than once"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.serviceConfig.Validate(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidatePolicyName(t *testing.T) {
+	for _, policyName := range []string{"canary-standard", "blue-green2", "a"} {
+		if err := models.ValidatePolicyName(policyName); err != nil {
+			t.Errorf("ValidatePolicyName(%q) = %v, want nil", policyName, err)
+		}
+	}
+	for _, policyName := range []string{"", "Canary", "-canary", "canary-", "canary standard", "456"} {
+		if err := models.ValidatePolicyName(policyName); err == nil {
+			t.Errorf("ValidatePolicyName(%q) should fail", policyName)
+		}
+	}
+}
+
+func TestServiceConfigPolicyReferences(t *testing.T) {
+	serviceConfig := validServiceConfig().withValidationPolicyIDs(7, 8).build()
+	references := serviceConfig.PolicyReferences()
+	validationReferences := references[len(references)-2:]
+	want := []models.ServiceConfigPolicyReference{
+		{Slot: models.ValidationPolicySlot, PolicyID: 7},
+		{Slot: models.ValidationPolicySlot, PolicyID: 8},
+	}
+	if !reflect.DeepEqual(validationReferences, want) {
+		t.Errorf("PolicyReferences() = %v, want validation references %v at the end", references, want)
+	}
+	if field := models.GetPolicySlotField(models.ValidationPolicySlot); field != "validation_policy_ids" {
+		t.Errorf("GetPolicySlotField() = %v, want validation_policy_ids", field)
+	}
+}
+
+func TestReleaseAgentConfigGetApplications(t *testing.T) {
+	releaseAgentConfig := models.ReleaseAgentConfig{
+		K8SNamespaceToApplicationID: map[string]uint64{
+			"shop":         2,
+			"default":      1,
+			"shop-staging": 2,
+			"backend":      1,
+		},
+	}
+	want := []models.ApplicationView{
+		{ID: 1, Namespace: "backend", Namespaces: []string{"backend", "default"}},
+		{ID: 2, Namespace: "shop", Namespaces: []string{"shop", "shop-staging"}},
+	}
+	if got := releaseAgentConfig.GetApplications(); !reflect.DeepEqual(got, want) {
+		t.Errorf("GetApplications() = %v, want %v", got, want)
+	}
+	if got := releaseAgentConfig.GetApplicationNamespaces(3); len(got) != 0 {
+		t.Errorf("GetApplicationNamespaces() of unknown application = %v, want none", got)
+	}
+}
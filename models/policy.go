@@ -0,0 +1,101 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	// ReleasePolicyType - type of policy used to release a new service version
+	ReleasePolicyType = "release"
+	// ValidationPolicyType - type of policy used to validate a service version
+	ValidationPolicyType = "validation"
+)
+
+// PolicyDocument - policy definition in the format accepted by put policy command
+type PolicyDocument struct {
+	Type       string            `json:"type"`
+	ID         uint64            `json:"id"`
+	Version    uint64            `json:"version"`
+	Steps      []PolicyStep      `json:"steps,omitempty"`
+	Conditions []PolicyCondition `json:"conditions,omitempty"`
+	Metrics    []PolicyMetric    `json:"metrics,omitempty"`
+	OnSuccess  []PolicyHook      `json:"onSuccess,omitempty"`
+	OnFailure  []PolicyHook      `json:"onFailure,omitempty"`
+}
+
+// PolicyStep - single step of a release policy
+type PolicyStep struct {
+	Source     PolicyStepSource   `json:"source"`
+	Target     PolicyStepTarget   `json:"target"`
+	EndAfter   PolicyStepEndAfter `json:"endAfter"`
+	Conditions []PolicyCondition  `json:"conditions,omitempty"`
+}
+
+// PolicyStepSource - traffic routed to the currently released version
+type PolicyStepSource struct {
+	Weight int64 `json:"weight"`
+}
+
+// PolicyStepTarget - traffic routed to the new version
+type PolicyStepTarget struct {
+	Weight            int64  `json:"weight"`
+	Condition         string `json:"condition,omitempty"`
+	ConditionStrength *int64 `json:"conditionStrength,omitempty"`
+}
+
+// PolicyStepEndAfter - defines when the step ends
+type PolicyStepEndAfter struct {
+	MaxDuration string `json:"maxDuration,omitempty"`
+}
+
+// PolicyCondition - condition evaluated on a metric
+type PolicyCondition struct {
+	Metric      string          `json:"metric"`
+	Budget      *float64        `json:"budget,omitempty"`
+	Interval    *PolicyInterval `json:"interval,omitempty"`
+	GracePeriod string          `json:"gracePeriod,omitempty"`
+	Threshold   *float64        `json:"threshold,omitempty"`
+	Operator    string          `json:"operator,omitempty"`
+}
+
+// PolicyInterval - interval in which condition is evaluated
+type PolicyInterval struct {
+	Type     string `json:"type"`
+	Duration string `json:"duration"`
+}
+
+// PolicyMetric - metric used by policy conditions
+type PolicyMetric struct {
+	Name  string            `json:"name"`
+	Type  string            `json:"type"`
+	Value PolicyMetricValue `json:"value"`
+}
+
+// PolicyMetricValue - source of metric values
+type PolicyMetricValue struct {
+	Source string `json:"source"`
+	Type   string `json:"type,omitempty"`
+}
+
+// PolicyHook - action executed when release finishes
+type PolicyHook struct {
+	Type  string          `json:"type"`
+	Value PolicyHookValue `json:"value"`
+}
+
+// PolicyHookValue - HTTP request sent by a policy hook
+type PolicyHookValue struct {
+	URL         string   `json:"url"`
+	HTTPRequest string   `json:"httpRequest"`
+	Headers     []string `json:"headers,omitempty"`
+}
+
+// ParsePolicyDocument - parses policy json text
+func ParsePolicyDocument(policyText string) (*PolicyDocument, error) {
+	var policy PolicyDocument
+	if err := json.Unmarshal([]byte(policyText), &policy); err != nil {
+		return nil, fmt.Errorf("cannot deserialize policy: %v", err)
+	}
+	return &policy, nil
+}
@@ -0,0 +1,253 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// PolicyChangeAdded - value exists only in the new policy
+	PolicyChangeAdded = "added"
+	// PolicyChangeRemoved - value exists only in the old policy
+	PolicyChangeRemoved = "removed"
+	// PolicyChangeModified - value exists in both policies but differs
+	PolicyChangeModified = "changed"
+)
+
+// PolicyChange - single semantic difference between two policies
+type PolicyChange struct {
+	Path   string `yaml:"path"`
+	Change string `yaml:"change"`
+	From   string `yaml:"from,omitempty"`
+	To     string `yaml:"to,omitempty"`
+}
+
+// DiffPolicies - compares meaning of two policies
+// steps and hooks are aligned by position, metrics and conditions are matched by metric name,
+// durations are compared by value so 1m and 1m0s are equal, header values are never reported,
+// both policies are expected in the form in which stored policies are shown so that only meaning differs
+func DiffPolicies(from PolicyDocument, to PolicyDocument) []PolicyChange {
+	differ := &policyDiffer{changes: make([]PolicyChange, 0)}
+
+	differ.compare("type", from.Type, to.Type)
+	differ.compare("version", fmt.Sprint(from.Version), fmt.Sprint(to.Version))
+
+	for i := 0; i < len(from.Steps) || i < len(to.Steps); i++ {
+		path := fmt.Sprintf("steps[%d]", i+1)
+		switch {
+		case i >= len(to.Steps):
+			differ.report(path, PolicyChangeRemoved, describeStep(from.Steps[i]), "")
+		case i >= len(from.Steps):
+			differ.report(path, PolicyChangeAdded, "", describeStep(to.Steps[i]))
+		default:
+			differ.diffStep(path, from.Steps[i], to.Steps[i])
+		}
+	}
+
+	differ.diffConditions("conditions", from.Conditions, to.Conditions)
+	differ.diffMetrics(from.Metrics, to.Metrics)
+	differ.diffHooks("onSuccess", from.OnSuccess, to.OnSuccess)
+	differ.diffHooks("onFailure", from.OnFailure, to.OnFailure)
+
+	return differ.changes
+}
+
+type policyDiffer struct {
+	changes []PolicyChange
+}
+
+func (d *policyDiffer) report(path, change, from, to string) {
+	d.changes = append(d.changes, PolicyChange{
+		Path:   path,
+		Change: change,
+		From:   from,
+		To:     to,
+	})
+}
+
+func (d *policyDiffer) compare(path, from, to string) {
+	switch {
+	case from == to:
+	case from == "":
+		d.report(path, PolicyChangeAdded, "", to)
+	case to == "":
+		d.report(path, PolicyChangeRemoved, from, "")
+	default:
+		d.report(path, PolicyChangeModified, from, to)
+	}
+}
+
+func (d *policyDiffer) compareDuration(path, from, to string) {
+	fromDuration, fromErr := time.ParseDuration(from)
+	toDuration, toErr := time.ParseDuration(to)
+	if fromErr == nil && toErr == nil && fromDuration == toDuration {
+		return
+	}
+	d.compare(path, from, to)
+}
+
+func (d *policyDiffer) diffStep(path string, from PolicyStep, to PolicyStep) {
+	d.compare(path+".source.weight", fmt.Sprint(from.Source.Weight), fmt.Sprint(to.Source.Weight))
+	d.compare(path+".target.weight", fmt.Sprint(from.Target.Weight), fmt.Sprint(to.Target.Weight))
+	d.compare(path+".target.condition", from.Target.Condition, to.Target.Condition)
+	d.compare(path+".target.conditionStrength", formatOptionalInt(from.Target.ConditionStrength), formatOptionalInt(to.Target.ConditionStrength))
+	d.compareDuration(path+".endAfter.maxDuration", from.EndAfter.MaxDuration, to.EndAfter.MaxDuration)
+	d.diffConditions(path+".conditions", from.Conditions, to.Conditions)
+}
+
+// diffConditions - matches conditions by metric and by position among the conditions of the same metric
+// so that a metric bounded by several conditions like ge and le is compared condition by condition
+func (d *policyDiffer) diffConditions(path string, from []PolicyCondition, to []PolicyCondition) {
+	fromKeys, toKeys := getConditionKeys(from), getConditionKeys(to)
+	toByKey := make(map[string]PolicyCondition, len(to))
+	for i, condition := range to {
+		toByKey[toKeys[i]] = condition
+	}
+	fromByKey := make(map[string]bool, len(from))
+	for i, fromCondition := range from {
+		fromByKey[fromKeys[i]] = true
+		conditionPath := fmt.Sprintf("%s[%s]", path, fromKeys[i])
+		toCondition, exists := toByKey[fromKeys[i]]
+		if !exists {
+			d.report(conditionPath, PolicyChangeRemoved, explainCondition(fromCondition), "")
+			continue
+		}
+		d.compare(conditionPath+".operator", fromCondition.Operator, toCondition.Operator)
+		d.compare(conditionPath+".threshold", formatOptionalFloat(fromCondition.Threshold), formatOptionalFloat(toCondition.Threshold))
+		d.compare(conditionPath+".budget", formatOptionalFloat(fromCondition.Budget), formatOptionalFloat(toCondition.Budget))
+		d.compareDuration(conditionPath+".gracePeriod", fromCondition.GracePeriod, toCondition.GracePeriod)
+		fromInterval, toInterval := PolicyInterval{}, PolicyInterval{}
+		if fromCondition.Interval != nil {
+			fromInterval = *fromCondition.Interval
+		}
+		if toCondition.Interval != nil {
+			toInterval = *toCondition.Interval
+		}
+		d.compare(conditionPath+".interval.type", fromInterval.Type, toInterval.Type)
+		d.compareDuration(conditionPath+".interval.duration", fromInterval.Duration, toInterval.Duration)
+	}
+	for i, toCondition := range to {
+		if !fromByKey[toKeys[i]] {
+			d.report(fmt.Sprintf("%s[%s]", path, toKeys[i]), PolicyChangeAdded, "", explainCondition(toCondition))
+		}
+	}
+}
+
+// getConditionKeys - gets keys of conditions which are metric names,
+// repeated conditions of a metric get their position appended like Health#2
+func getConditionKeys(conditions []PolicyCondition) []string {
+	keys := make([]string, len(conditions))
+	occurrences := make(map[string]int, len(conditions))
+	for i, condition := range conditions {
+		occurrences[condition.Metric]++
+		keys[i] = condition.Metric
+		if occurrence := occurrences[condition.Metric]; occurrence > 1 {
+			keys[i] = fmt.Sprintf("%s#%d", condition.Metric, occurrence)
+		}
+	}
+	return keys
+}
+
+func (d *policyDiffer) diffMetrics(from []PolicyMetric, to []PolicyMetric) {
+	toByName := make(map[string]PolicyMetric, len(to))
+	for _, metric := range to {
+		toByName[metric.Name] = metric
+	}
+	fromByName := make(map[string]bool, len(from))
+	for _, fromMetric := range from {
+		fromByName[fromMetric.Name] = true
+		path := fmt.Sprintf("metrics[%s]", fromMetric.Name)
+		toMetric, exists := toByName[fromMetric.Name]
+		if !exists {
+			d.report(path, PolicyChangeRemoved, describeMetric(fromMetric), "")
+			continue
+		}
+		d.compare(path+".type", fromMetric.Type, toMetric.Type)
+		d.compare(path+".value.source", fromMetric.Value.Source, toMetric.Value.Source)
+		d.compare(path+".value.type", fromMetric.Value.Type, toMetric.Value.Type)
+	}
+	for _, toMetric := range to {
+		if !fromByName[toMetric.Name] {
+			d.report(fmt.Sprintf("metrics[%s]", toMetric.Name), PolicyChangeAdded, "", describeMetric(toMetric))
+		}
+	}
+}
+
+func (d *policyDiffer) diffHooks(path string, from []PolicyHook, to []PolicyHook) {
+	for i := 0; i < len(from) || i < len(to); i++ {
+		hookPath := fmt.Sprintf("%s[%d]", path, i+1)
+		switch {
+		case i >= len(to):
+			d.report(hookPath, PolicyChangeRemoved, describeHook(from[i]), "")
+		case i >= len(from):
+			d.report(hookPath, PolicyChangeAdded, "", describeHook(to[i]))
+		default:
+			d.compare(hookPath+".type", from[i].Type, to[i].Type)
+			d.compare(hookPath+".httpRequest", from[i].Value.HTTPRequest, to[i].Value.HTTPRequest)
+			d.compare(hookPath+".url", from[i].Value.URL, to[i].Value.URL)
+			d.diffHeaders(hookPath+".headers", from[i].Value.Headers, to[i].Value.Headers)
+		}
+	}
+}
+
+func (d *policyDiffer) diffHeaders(path string, from []string, to []string) {
+	fromHeaders := parseHookHeaders(from)
+	toHeaders := parseHookHeaders(to)
+	for _, header := range from {
+		name := getHookHeaderName(header)
+		toValue, exists := toHeaders[name]
+		if !exists {
+			d.report(fmt.Sprintf("%s[%s]", path, name), PolicyChangeRemoved, "", "")
+		} else if toValue != fromHeaders[name] {
+			d.report(fmt.Sprintf("%s[%s]", path, name), PolicyChangeModified, "", "")
+		}
+	}
+	for _, header := range to {
+		name := getHookHeaderName(header)
+		if _, exists := fromHeaders[name]; !exists {
+			d.report(fmt.Sprintf("%s[%s]", path, name), PolicyChangeAdded, "", "")
+		}
+	}
+}
+
+func parseHookHeaders(headers []string) map[string]string {
+	values := make(map[string]string, len(headers))
+	for _, header := range headers {
+		values[getHookHeaderName(header)] = header
+	}
+	return values
+}
+
+func getHookHeaderName(header string) string {
+	return strings.ToLower(strings.TrimSpace(strings.SplitN(header, ":", 2)[0]))
+}
+
+func describeStep(step PolicyStep) string {
+	return fmt.Sprintf("source %d%%, target %d%%, max duration %s", step.Source.Weight, step.Target.Weight, step.EndAfter.MaxDuration)
+}
+
+func describeMetric(metric PolicyMetric) string {
+	if metric.Value.Type != "" {
+		return fmt.Sprintf("%s from %s (%s)", metric.Type, metric.Value.Source, metric.Value.Type)
+	}
+	return fmt.Sprintf("%s from %s", metric.Type, metric.Value.Source)
+}
+
+func describeHook(hook PolicyHook) string {
+	return fmt.Sprintf("%s %s %s", hook.Type, hook.Value.HTTPRequest, hook.Value.URL)
+}
+
+func formatOptionalInt(value *int64) string {
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprint(*value)
+}
+
+func formatOptionalFloat(value *float64) string {
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprintf("%g", *value)
+}
@@ -0,0 +1,73 @@
+package models_test
+
+import (
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	"github.com/magneticio/forklift/models"
+)
+
+func TestDiffPolicies(t *testing.T) {
+	policyText, err := ioutil.ReadFile("../integrationtests/resources/validpolicy.json")
+	if err != nil {
+		t.Fatalf("cannot read policy: %v", err)
+	}
+	from, err := models.ParsePolicyDocument(string(policyText))
+	if err != nil {
+		t.Fatalf("cannot parse policy: %v", err)
+	}
+	to, err := models.ParsePolicyDocument(string(policyText))
+	if err != nil {
+		t.Fatalf("cannot parse policy: %v", err)
+	}
+
+	if got := models.DiffPolicies(*from, *to); len(got) != 0 {
+		t.Errorf("DiffPolicies() of equal policies = %v, want no changes", got)
+	}
+
+	threshold := float64(2)
+	to.Version = 13
+	to.Steps[0].EndAfter.MaxDuration = "1m"
+	to.Steps[0].Conditions[0].Threshold = &threshold
+	to.Steps[0].Conditions = to.Steps[0].Conditions[:2]
+	to.Steps[1].Target.Weight = 10
+	to.Steps[1].Source.Weight = 90
+	to.OnSuccess[0].Value.Headers = []string{"authorization: Bearer zzz"}
+
+	want := []models.PolicyChange{
+		{Path: "version", Change: models.PolicyChangeModified, From: "12", To: "13"},
+		{Path: "steps[1].conditions[Health].threshold", Change: models.PolicyChangeModified, From: "1", To: "2"},
+		{Path: "steps[1].conditions[available replicas]", Change: models.PolicyChangeRemoved, From: "available replicas >= 80, budget 90% of samples, rolling interval 5m0s, grace period 1m0s"},
+		{Path: "steps[2].source.weight", Change: models.PolicyChangeModified, From: "100", To: "90"},
+		{Path: "steps[2].target.weight", Change: models.PolicyChangeModified, From: "0", To: "10"},
+		{Path: "onSuccess[1].headers[authorization]", Change: models.PolicyChangeModified},
+	}
+	if got := models.DiffPolicies(*from, *to); !reflect.DeepEqual(got, want) {
+		t.Errorf("DiffPolicies() = %v, want %v", got, want)
+	}
+}
+
+func TestDiffPoliciesWithSeveralConditionsOfOneMetric(t *testing.T) {
+	newPolicy := func(upperThreshold float64) models.PolicyDocument {
+		lowerThreshold := float64(1)
+		return models.PolicyDocument{
+			Steps: []models.PolicyStep{{
+				Conditions: []models.PolicyCondition{
+					{Metric: "Health", Operator: "ge", Threshold: &lowerThreshold},
+					{Metric: "Health", Operator: "le", Threshold: &upperThreshold},
+				},
+			}},
+		}
+	}
+
+	if got := models.DiffPolicies(newPolicy(5), newPolicy(5)); len(got) != 0 {
+		t.Errorf("DiffPolicies() of equal policies = %v, want no changes", got)
+	}
+	want := []models.PolicyChange{
+		{Path: "steps[1].conditions[Health#2].threshold", Change: models.PolicyChangeModified, From: "5", To: "10"},
+	}
+	if got := models.DiffPolicies(newPolicy(5), newPolicy(10)); !reflect.DeepEqual(got, want) {
+		t.Errorf("DiffPolicies() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,617 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ghodss/yaml"
+)
+
+const defaultIntervalType = "rolling"
+
+var policyDSLConditionKeywords = map[string]bool{
+	"within": true,
+	"budget": true,
+	"after":  true,
+	"and":    true,
+}
+
+var policyDSLKeywords = map[string]bool{
+	"type": true, "id": true, "version": true, "metric": true, "from": true, "condition": true,
+	"step": true, "source": true, "for": true, "if": true, "strength": true, "when": true,
+	"on": true, "success": true, "failure": true, "header": true,
+	"within": true, "budget": true, "after": true, "and": true,
+}
+
+type policyDSLToken struct {
+	text   string
+	quoted bool
+}
+
+type policyDSLParser struct {
+	policy *PolicyDocument
+	tokens []policyDSLToken
+	pos    int
+}
+
+// CompilePolicyDSL - compiles policy written in the policy DSL to policy document
+// every line is a statement like "step 10% for 1m when Health >= 1 within 5m budget 70",
+// # starts a comment and values containing spaces are double quoted
+func CompilePolicyDSL(dslText string) (*PolicyDocument, error) {
+	parser := &policyDSLParser{
+		policy: &PolicyDocument{Type: ReleasePolicyType},
+	}
+	for i, line := range strings.Split(dslText, "\n") {
+		tokens, err := tokenizePolicyDSLLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", i+1, err)
+		}
+		if len(tokens) == 0 {
+			continue
+		}
+		parser.tokens = tokens
+		parser.pos = 0
+		if err := parser.parseStatement(); err != nil {
+			return nil, fmt.Errorf("line %d: %v", i+1, err)
+		}
+	}
+	return parser.policy, nil
+}
+
+// CompilePolicyYAML - compiles policy written in YAML with the structure of the policy JSON format
+// members the policy format does not know are rejected instead of being silently dropped
+func CompilePolicyYAML(yamlText string) (*PolicyDocument, error) {
+	policyJSON, err := yaml.YAMLToJSON([]byte(yamlText))
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse policy yaml: %v", err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(policyJSON))
+	decoder.DisallowUnknownFields()
+	policy := &PolicyDocument{Type: ReleasePolicyType}
+	if err := decoder.Decode(policy); err != nil {
+		return nil, fmt.Errorf("cannot deserialize policy: %v", err)
+	}
+	return policy, nil
+}
+
+func tokenizePolicyDSLLine(line string) ([]policyDSLToken, error) {
+	tokens := make([]policyDSLToken, 0)
+	for i := 0; i < len(line); {
+		switch {
+		case line[i] == ' ' || line[i] == '\t' || line[i] == '\r':
+			i++
+		case line[i] == '#':
+			return tokens, nil
+		case line[i] == '"':
+			end := i + 1
+			for end < len(line) && line[end] != '"' {
+				if line[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(line) {
+				return nil, fmt.Errorf("unterminated quoted value")
+			}
+			text, err := strconv.Unquote(line[i : end+1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid quoted value %s", line[i:end+1])
+			}
+			tokens = append(tokens, policyDSLToken{text: text, quoted: true})
+			i = end + 1
+		default:
+			end := i
+			for end < len(line) && line[end] != ' ' && line[end] != '\t' && line[end] != '\r' {
+				end++
+			}
+			tokens = append(tokens, policyDSLToken{text: line[i:end]})
+			i = end
+		}
+	}
+	return tokens, nil
+}
+
+func (p *policyDSLParser) atEnd() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *policyDSLParser) isKeyword(keyword string) bool {
+	return !p.atEnd() && !p.tokens[p.pos].quoted && p.tokens[p.pos].text == keyword
+}
+
+func (p *policyDSLParser) next(what string) (string, error) {
+	if p.atEnd() {
+		return "", fmt.Errorf("%s expected", what)
+	}
+	token := p.tokens[p.pos]
+	p.pos++
+	return token.text, nil
+}
+
+func (p *policyDSLParser) expect(keyword string) error {
+	if !p.isKeyword(keyword) {
+		return fmt.Errorf("'%s' expected", keyword)
+	}
+	p.pos++
+	return nil
+}
+
+func (p *policyDSLParser) nextNumber(what string) (float64, error) {
+	text, err := p.next(what)
+	if err != nil {
+		return 0, err
+	}
+	number, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s '%s'", what, text)
+	}
+	return number, nil
+}
+
+func (p *policyDSLParser) nextPercentage(what string) (int64, error) {
+	text, err := p.next(what)
+	if err != nil {
+		return 0, err
+	}
+	percentage, err := strconv.ParseInt(strings.TrimSuffix(text, "%"), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s '%s'", what, text)
+	}
+	return percentage, nil
+}
+
+func (p *policyDSLParser) nextUint(what string) (uint64, error) {
+	text, err := p.next(what)
+	if err != nil {
+		return 0, err
+	}
+	number, err := strconv.ParseUint(text, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s '%s'", what, text)
+	}
+	return number, nil
+}
+
+func (p *policyDSLParser) parseStatement() error {
+	statement, _ := p.next("statement")
+	var err error
+	switch statement {
+	case "type":
+		p.policy.Type, err = p.next("policy type")
+	case "id":
+		p.policy.ID, err = p.nextUint("policy id")
+	case "version":
+		p.policy.Version, err = p.nextUint("policy version")
+	case "metric":
+		err = p.parseMetric()
+	case "condition":
+		p.policy.Conditions, err = p.parseConditions(p.policy.Conditions)
+	case "step":
+		err = p.parseStep()
+	case "when":
+		if len(p.policy.Steps) == 0 {
+			return fmt.Errorf("'when' must follow a step")
+		}
+		step := &p.policy.Steps[len(p.policy.Steps)-1]
+		step.Conditions, err = p.parseConditions(step.Conditions)
+	case "on":
+		err = p.parseHook()
+	default:
+		return fmt.Errorf("unknown statement '%s'", statement)
+	}
+	if err != nil {
+		return err
+	}
+	if !p.atEnd() {
+		return fmt.Errorf("unexpected '%s'", p.tokens[p.pos].text)
+	}
+	return nil
+}
+
+func (p *policyDSLParser) parseMetric() error {
+	var metric PolicyMetric
+	var err error
+	if metric.Name, err = p.next("metric name"); err != nil {
+		return err
+	}
+	if metric.Type, err = p.next("metric type"); err != nil {
+		return err
+	}
+	if err := p.expect("from"); err != nil {
+		return err
+	}
+	if metric.Value.Source, err = p.next("metric source"); err != nil {
+		return err
+	}
+	if p.isKeyword("type") {
+		p.pos++
+		if metric.Value.Type, err = p.next("metric value type"); err != nil {
+			return err
+		}
+	}
+	p.policy.Metrics = append(p.policy.Metrics, metric)
+	return nil
+}
+
+func (p *policyDSLParser) parseStep() error {
+	var step PolicyStep
+	var err error
+	if step.Target.Weight, err = p.nextPercentage("target weight"); err != nil {
+		return err
+	}
+	step.Source.Weight = 100 - step.Target.Weight
+	if p.isKeyword("source") {
+		p.pos++
+		if step.Source.Weight, err = p.nextPercentage("source weight"); err != nil {
+			return err
+		}
+	}
+	if err := p.expect("for"); err != nil {
+		return err
+	}
+	if step.EndAfter.MaxDuration, err = p.next("step duration"); err != nil {
+		return err
+	}
+	if p.isKeyword("if") {
+		p.pos++
+		if step.Target.Condition, err = p.next("target condition"); err != nil {
+			return err
+		}
+		if p.isKeyword("strength") {
+			p.pos++
+			conditionStrength, err := p.nextPercentage("condition strength")
+			if err != nil {
+				return err
+			}
+			step.Target.ConditionStrength = &conditionStrength
+		}
+	}
+	if p.isKeyword("when") {
+		p.pos++
+		if step.Conditions, err = p.parseConditions(nil); err != nil {
+			return err
+		}
+	}
+	p.policy.Steps = append(p.policy.Steps, step)
+	return nil
+}
+
+func (p *policyDSLParser) parseConditions(conditions []PolicyCondition) ([]PolicyCondition, error) {
+	for {
+		condition, err := p.parseCondition()
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, *condition)
+		if !p.isKeyword("and") {
+			return conditions, nil
+		}
+		p.pos++
+	}
+}
+
+func (p *policyDSLParser) parseCondition() (*PolicyCondition, error) {
+	var condition PolicyCondition
+	var err error
+	if condition.Metric, err = p.next("metric name"); err != nil {
+		return nil, err
+	}
+	if !p.atEnd() && !p.tokens[p.pos].quoted {
+		for _, candidate := range policyOperatorsBySymbol {
+			if p.tokens[p.pos].text == candidate.symbol {
+				p.pos++
+				threshold, err := p.nextNumber("threshold")
+				if err != nil {
+					return nil, err
+				}
+				condition.Operator = candidate.operator
+				condition.Threshold = &threshold
+				break
+			}
+		}
+	}
+	for !p.atEnd() && !p.isKeyword("and") {
+		quoted := p.tokens[p.pos].quoted
+		keyword, _ := p.next("condition option")
+		if quoted {
+			keyword = strconv.Quote(keyword)
+		}
+		switch keyword {
+		case "within":
+			interval := PolicyInterval{Type: defaultIntervalType}
+			if interval.Duration, err = p.next("interval duration"); err != nil {
+				return nil, err
+			}
+			if !p.atEnd() && (p.tokens[p.pos].quoted || !policyDSLConditionKeywords[p.tokens[p.pos].text]) {
+				interval.Type, _ = p.next("interval type")
+			}
+			condition.Interval = &interval
+		case "budget":
+			budget, err := p.nextNumber("budget")
+			if err != nil {
+				return nil, err
+			}
+			condition.Budget = &budget
+		case "after":
+			if condition.GracePeriod, err = p.next("grace period"); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("unexpected '%s' in condition on metric '%s'", keyword, condition.Metric)
+		}
+	}
+	return &condition, nil
+}
+
+func (p *policyDSLParser) parseHook() error {
+	event, err := p.next("'success' or 'failure'")
+	if err != nil {
+		return err
+	}
+	var hook PolicyHook
+	if hook.Type, err = p.next("hook type"); err != nil {
+		return err
+	}
+	if hook.Value.HTTPRequest, err = p.next("http method"); err != nil {
+		return err
+	}
+	if hook.Value.URL, err = p.next("url"); err != nil {
+		return err
+	}
+	for p.isKeyword("header") {
+		p.pos++
+		header, err := p.next("header")
+		if err != nil {
+			return err
+		}
+		hook.Value.Headers = append(hook.Value.Headers, header)
+	}
+	switch event {
+	case "success":
+		p.policy.OnSuccess = append(p.policy.OnSuccess, hook)
+	case "failure":
+		p.policy.OnFailure = append(p.policy.OnFailure, hook)
+	default:
+		return fmt.Errorf("unknown event '%s', 'success' or 'failure' expected", event)
+	}
+	return nil
+}
+
+// ConvertPolicyToDSL - writes policy json text in the policy DSL
+// the DSL text is compiled back and compared with the policy so that conversion fails
+// instead of silently dropping fields or values the DSL cannot represent
+func ConvertPolicyToDSL(policyText string) (string, error) {
+	policy, err := ParsePolicyDocument(policyText)
+	if err != nil {
+		return "", err
+	}
+	dslText, err := DecompilePolicyDSL(*policy)
+	if err != nil {
+		return "", err
+	}
+	compiledPolicy, err := CompilePolicyDSL(dslText)
+	if err != nil {
+		return "", fmt.Errorf("policy cannot be written in the DSL: %v", err)
+	}
+	compiledText, err := MarshalPolicyDocument(*compiledPolicy)
+	if err != nil {
+		return "", err
+	}
+
+	original, err := decodePolicyJSON(policyText)
+	if err != nil {
+		return "", fmt.Errorf("cannot deserialize policy: %v", err)
+	}
+	compiled, err := decodePolicyJSON(compiledText)
+	if err != nil {
+		return "", fmt.Errorf("cannot deserialize policy: %v", err)
+	}
+	if lostPath, lost := findPolicyDSLLoss("", original, compiled); lost {
+		return "", fmt.Errorf("policy cannot be written in the DSL without losing '%s'", lostPath)
+	}
+	return dslText, nil
+}
+
+func decodePolicyJSON(policyText string) (interface{}, error) {
+	decoder := json.NewDecoder(strings.NewReader(policyText))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// findPolicyDSLLoss - finds the first path at which compiled policy differs from the original one
+// missing members, nulls, empty strings, arrays and objects are all treated as empty and numbers are compared by value,
+// zero numbers written by the compiled policy for missing members are defaults and not a difference
+func findPolicyDSLLoss(path string, original interface{}, compiled interface{}) (string, bool) {
+	if isEmptyPolicyValue(original) && isEmptyPolicyValue(compiled) {
+		return "", false
+	}
+	if number, isNumber := compiled.(json.Number); original == nil && isNumber {
+		if value, err := number.Float64(); err == nil && value == 0 {
+			return "", false
+		}
+	}
+	switch original := original.(type) {
+	case map[string]interface{}:
+		compiled, isObject := compiled.(map[string]interface{})
+		if !isObject {
+			return path, true
+		}
+		names := make([]string, 0, len(original)+len(compiled))
+		for name := range original {
+			names = append(names, name)
+		}
+		for name := range compiled {
+			if _, exists := original[name]; !exists {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			memberPath := name
+			if path != "" {
+				memberPath = path + "." + name
+			}
+			if lostPath, lost := findPolicyDSLLoss(memberPath, original[name], compiled[name]); lost {
+				return lostPath, true
+			}
+		}
+		return "", false
+	case []interface{}:
+		compiled, isArray := compiled.([]interface{})
+		if !isArray || len(original) != len(compiled) {
+			return path, true
+		}
+		for i := range original {
+			if lostPath, lost := findPolicyDSLLoss(fmt.Sprintf("%s[%d]", path, i+1), original[i], compiled[i]); lost {
+				return lostPath, true
+			}
+		}
+		return "", false
+	case json.Number:
+		compiled, isNumber := compiled.(json.Number)
+		if !isNumber {
+			return path, true
+		}
+		if original == compiled {
+			return "", false
+		}
+		originalFloat, originalErr := original.Float64()
+		compiledFloat, compiledErr := compiled.Float64()
+		return path, originalErr != nil || compiledErr != nil || originalFloat != compiledFloat
+	}
+	return path, original != compiled
+}
+
+func isEmptyPolicyValue(value interface{}) bool {
+	switch value := value.(type) {
+	case nil:
+		return true
+	case string:
+		return value == ""
+	case []interface{}:
+		return len(value) == 0
+	case map[string]interface{}:
+		return len(value) == 0
+	}
+	return false
+}
+
+// DecompilePolicyDSL - writes policy document in the policy DSL
+// conditions with operators the DSL does not know cannot be written
+func DecompilePolicyDSL(policy PolicyDocument) (string, error) {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "type %s\n", quotePolicyDSLValue(policy.Type))
+	if policy.ID != 0 {
+		fmt.Fprintf(&sb, "id %d\n", policy.ID)
+	}
+	if policy.Version != 0 {
+		fmt.Fprintf(&sb, "version %d\n", policy.Version)
+	}
+
+	if len(policy.Metrics) > 0 {
+		sb.WriteString("\n")
+	}
+	for _, metric := range policy.Metrics {
+		fmt.Fprintf(&sb, "metric %s %s from %s", quotePolicyDSLValue(metric.Name), quotePolicyDSLValue(metric.Type), quotePolicyDSLValue(metric.Value.Source))
+		if metric.Value.Type != "" {
+			fmt.Fprintf(&sb, " type %s", quotePolicyDSLValue(metric.Value.Type))
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(policy.Conditions) > 0 {
+		sb.WriteString("\n")
+	}
+	for _, condition := range policy.Conditions {
+		conditionText, err := decompilePolicyDSLCondition(condition)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&sb, "condition %s\n", conditionText)
+	}
+
+	for _, step := range policy.Steps {
+		fmt.Fprintf(&sb, "\nstep %d%%", step.Target.Weight)
+		if step.Source.Weight != 100-step.Target.Weight {
+			fmt.Fprintf(&sb, " source %d%%", step.Source.Weight)
+		}
+		fmt.Fprintf(&sb, " for %s", quotePolicyDSLValue(step.EndAfter.MaxDuration))
+		if step.Target.Condition != "" {
+			fmt.Fprintf(&sb, " if %s", quotePolicyDSLValue(step.Target.Condition))
+			if step.Target.ConditionStrength != nil {
+				fmt.Fprintf(&sb, " strength %d%%", *step.Target.ConditionStrength)
+			}
+		}
+		sb.WriteString("\n")
+		for _, condition := range step.Conditions {
+			conditionText, err := decompilePolicyDSLCondition(condition)
+			if err != nil {
+				return "", err
+			}
+			fmt.Fprintf(&sb, "  when %s\n", conditionText)
+		}
+	}
+
+	writePolicyDSLHooks(&sb, "success", policy.OnSuccess)
+	writePolicyDSLHooks(&sb, "failure", policy.OnFailure)
+
+	return sb.String(), nil
+}
+
+func decompilePolicyDSLCondition(condition PolicyCondition) (string, error) {
+	parts := []string{quotePolicyDSLValue(condition.Metric)}
+	if condition.Threshold != nil || condition.Operator != "" {
+		operator, known := policyOperatorSymbols[condition.Operator]
+		if !known || condition.Threshold == nil {
+			return "", fmt.Errorf("condition on metric '%s' with operator '%s' cannot be written in the DSL", condition.Metric, condition.Operator)
+		}
+		parts = append(parts, operator, strconv.FormatFloat(*condition.Threshold, 'g', -1, 64))
+	}
+	if condition.Interval != nil {
+		parts = append(parts, "within", quotePolicyDSLValue(condition.Interval.Duration))
+		if condition.Interval.Type != defaultIntervalType {
+			parts = append(parts, quotePolicyDSLValue(condition.Interval.Type))
+		}
+	}
+	if condition.Budget != nil {
+		parts = append(parts, "budget", strconv.FormatFloat(*condition.Budget, 'g', -1, 64))
+	}
+	if condition.GracePeriod != "" {
+		parts = append(parts, "after", quotePolicyDSLValue(condition.GracePeriod))
+	}
+	return strings.Join(parts, " "), nil
+}
+
+func writePolicyDSLHooks(sb *strings.Builder, event string, hooks []PolicyHook) {
+	if len(hooks) > 0 {
+		sb.WriteString("\n")
+	}
+	for _, hook := range hooks {
+		fmt.Fprintf(sb, "on %s %s %s %s", event, quotePolicyDSLValue(hook.Type), quotePolicyDSLValue(hook.Value.HTTPRequest), quotePolicyDSLValue(hook.Value.URL))
+		for _, header := range hook.Value.Headers {
+			fmt.Fprintf(sb, " header %s", quotePolicyDSLValue(header))
+		}
+		sb.WriteString("\n")
+	}
+}
+
+func quotePolicyDSLValue(value string) string {
+	if value == "" || policyDSLKeywords[value] || strings.ContainsAny(value, " \t\r\n\"#") {
+		return strconv.Quote(value)
+	}
+	for _, candidate := range policyOperatorsBySymbol {
+		if value == candidate.symbol {
+			return strconv.Quote(value)
+		}
+	}
+	return value
+}
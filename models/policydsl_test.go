@@ -0,0 +1,147 @@
+package models_test
+
+import (
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	"github.com/magneticio/forklift/models"
+)
+
+func TestCompilePolicyDSL(t *testing.T) {
+	dslText := `# canary with a single step
+type release
+metric Health TimeBased from k8s-deployment-health
+metric "available replicas" TimeBased from k8s-deployment-health type availablereplicas
+
+step 10% for 1m when Health >= 1 within 5m budget 70 after 1m
+  when "available replicas" >= 80 within 5m budget 90
+on failure http POST http://test.local header "authorization: Bearer token"
+`
+	policy, err := models.CompilePolicyDSL(dslText)
+	if err != nil {
+		t.Fatalf("CompilePolicyDSL() error = %v", err)
+	}
+	if len(policy.Steps) != 1 || policy.Steps[0].Source.Weight != 90 || policy.Steps[0].Target.Weight != 10 {
+		t.Fatalf("CompilePolicyDSL() steps = %+v", policy.Steps)
+	}
+	conditions := policy.Steps[0].Conditions
+	if len(conditions) != 2 || conditions[1].Metric != "available replicas" || *conditions[1].Threshold != 80 || conditions[1].Interval.Type != "rolling" {
+		t.Errorf("CompilePolicyDSL() conditions = %+v", conditions)
+	}
+	if issues := models.LintPolicy(mustMarshalPolicy(t, *policy)); len(issues) > 0 {
+		t.Errorf("compiled policy has problems: %v", issues)
+	}
+
+	for dslText, want := range map[string]string{
+		"step 10% when Health >= 1":             "line 1: 'for' expected",
+		"type release\nwhen Health >= 1":        "line 2: 'when' must follow a step",
+		"step 10% for 1m when Health >= high":   "line 1: invalid threshold 'high'",
+		"step 10% for 1m when Health soon 1m":   "line 1: unexpected 'soon' in condition on metric 'Health'",
+		"metric \"available replicas TimeBased": "line 1: unterminated quoted value",
+	} {
+		if _, err := models.CompilePolicyDSL(dslText); err == nil || err.Error() != want {
+			t.Errorf("CompilePolicyDSL(%q) error = %v, want %v", dslText, err, want)
+		}
+	}
+}
+
+func TestDecompilePolicyDSL(t *testing.T) {
+	policyText, err := ioutil.ReadFile("../integrationtests/resources/validpolicy.json")
+	if err != nil {
+		t.Fatalf("cannot read policy: %v", err)
+	}
+	policy, err := models.ParsePolicyDocument(string(policyText))
+	if err != nil {
+		t.Fatalf("cannot parse policy: %v", err)
+	}
+
+	dslText, err := models.DecompilePolicyDSL(*policy)
+	if err != nil {
+		t.Fatalf("DecompilePolicyDSL() error = %v", err)
+	}
+	compiledPolicy, err := models.CompilePolicyDSL(dslText)
+	if err != nil {
+		t.Fatalf("CompilePolicyDSL() error = %v for\n%s", err, dslText)
+	}
+	if !reflect.DeepEqual(compiledPolicy, policy) {
+		t.Errorf("CompilePolicyDSL(DecompilePolicyDSL()) = %+v, want %+v", compiledPolicy, policy)
+	}
+	if redecompiled, _ := models.DecompilePolicyDSL(*compiledPolicy); redecompiled != dslText {
+		t.Errorf("DecompilePolicyDSL() is not stable:\n%s\n%s", dslText, redecompiled)
+	}
+}
+
+func TestConvertPolicyToDSL(t *testing.T) {
+	policyText, err := ioutil.ReadFile("../integrationtests/resources/validpolicy.json")
+	if err != nil {
+		t.Fatalf("cannot read policy: %v", err)
+	}
+	if _, err := models.ConvertPolicyToDSL(string(policyText)); err != nil {
+		t.Errorf("ConvertPolicyToDSL() error = %v", err)
+	}
+
+	for policyText, want := range map[string]string{
+		`{"type":"release","steps":[{"source":{"weight":90},"target":{"weight":10},"endAfter":{"maxDuration":"1m"},"conditions":[{"metric":"Health","operator":"between","threshold":1}]}]}`: "condition on metric 'Health' with operator 'between' cannot be written in the DSL",
+		`{"type":"release","conditions":[{"metric":"Health","operator":"ge"}]}`:                                                            "condition on metric 'Health' with operator 'ge' cannot be written in the DSL",
+		`{"type":"release","steps":[{"source":{"weight":90},"target":{"weight":10},"endAfter":{"maxDuration":"1m","minSamples":5}}]}`:      "policy cannot be written in the DSL without losing 'steps[1].endAfter.minSamples'",
+		`{"type":"release","description":"canary","onFailure":[{"type":"http","value":{"httpRequest":"POST","url":"http://test.local"}}]}`: "policy cannot be written in the DSL without losing 'description'",
+	} {
+		if _, err := models.ConvertPolicyToDSL(policyText); err == nil || err.Error() != want {
+			t.Errorf("ConvertPolicyToDSL(%s) error = %v, want %v", policyText, err, want)
+		}
+	}
+}
+
+func TestCompilePolicyYAML(t *testing.T) {
+	yamlText := `
+metrics:
+  - name: Health
+    type: TimeBased
+    value:
+      source: k8s-deployment-health
+steps:
+  - source:
+      weight: 90
+    target:
+      weight: 10
+    endAfter:
+      maxDuration: 1m
+    conditions:
+      - metric: Health
+        operator: ge
+        threshold: 1
+        budget: 70
+        interval:
+          type: rolling
+          duration: 5m
+`
+	policy, err := models.CompilePolicyYAML(yamlText)
+	if err != nil {
+		t.Fatalf("CompilePolicyYAML() error = %v", err)
+	}
+	want, err := models.CompilePolicyDSL("metric Health TimeBased from k8s-deployment-health\nstep 10% for 1m when Health >= 1 within 5m budget 70")
+	if err != nil {
+		t.Fatalf("CompilePolicyDSL() error = %v", err)
+	}
+	if !reflect.DeepEqual(policy, want) {
+		t.Errorf("CompilePolicyYAML() = %+v, want %+v", policy, want)
+	}
+
+	for yamlText, want := range map[string]string{
+		"steps:\n  - target:\n      weight: 10\n    minSamples: 5\n": `cannot deserialize policy: json: unknown field "minSamples"`,
+		"steps: 10% for 1m\n": "cannot deserialize policy: json: cannot unmarshal string into Go struct field PolicyDocument.steps of type []models.PolicyStep",
+	} {
+		if _, err := models.CompilePolicyYAML(yamlText); err == nil || err.Error() != want {
+			t.Errorf("CompilePolicyYAML(%q) error = %v, want %v", yamlText, err, want)
+		}
+	}
+}
+
+func mustMarshalPolicy(t *testing.T, policy models.PolicyDocument) string {
+	policyText, err := models.MarshalPolicyDocument(policy)
+	if err != nil {
+		t.Fatalf("MarshalPolicyDocument() error = %v", err)
+	}
+	return policyText
+}
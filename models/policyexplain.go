@@ -0,0 +1,115 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+const policyWeightBarWidth = 20
+
+var policyOperatorSymbols = map[string]string{
+	"eq": "==",
+	"ne": "!=",
+	"gt": ">",
+	"ge": ">=",
+	"lt": "<",
+	"le": "<=",
+}
+
+// ExplainPolicy - renders policy definition as human readable text
+func ExplainPolicy(policy PolicyDocument) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "Policy %d (%s, version %d)\n", policy.ID, policy.Type, policy.Version)
+
+	for i, step := range policy.Steps {
+		fmt.Fprintf(&sb, "\nStep %d of %d\n", i+1, len(policy.Steps))
+		fmt.Fprintf(&sb, "  source  %s\n", renderWeightBar(step.Source.Weight))
+		fmt.Fprintf(&sb, "  target  %s\n", renderWeightBar(step.Target.Weight))
+		if step.Target.Condition != "" {
+			if step.Target.ConditionStrength != nil {
+				fmt.Fprintf(&sb, "  target condition: %s (strength %d%%)\n", step.Target.Condition, *step.Target.ConditionStrength)
+			} else {
+				fmt.Fprintf(&sb, "  target condition: %s\n", step.Target.Condition)
+			}
+		}
+		if step.EndAfter.MaxDuration != "" {
+			fmt.Fprintf(&sb, "  ends after: %s\n", step.EndAfter.MaxDuration)
+		}
+		writeConditions(&sb, "  ", "conditions:", step.Conditions)
+	}
+
+	if len(policy.Conditions) > 0 {
+		sb.WriteString("\n")
+		writeConditions(&sb, "", "Conditions:", policy.Conditions)
+	}
+
+	sb.WriteString("\n")
+	writeHooks(&sb, "On success:", policy.OnSuccess)
+	writeHooks(&sb, "On failure:", policy.OnFailure)
+
+	return sb.String()
+}
+
+func renderWeightBar(weight int64) string {
+	filled := int(weight * policyWeightBarWidth / 100)
+	if filled < 0 {
+		filled = 0
+	}
+	if filled > policyWeightBarWidth {
+		filled = policyWeightBarWidth
+	}
+	return fmt.Sprintf("[%s%s] %3d%%", strings.Repeat("#", filled), strings.Repeat(".", policyWeightBarWidth-filled), weight)
+}
+
+func writeConditions(sb *strings.Builder, indent string, title string, conditions []PolicyCondition) {
+	if len(conditions) == 0 {
+		return
+	}
+	fmt.Fprintf(sb, "%s%s\n", indent, title)
+	for _, condition := range conditions {
+		fmt.Fprintf(sb, "%s  - %s\n", indent, explainCondition(condition))
+	}
+}
+
+func explainCondition(condition PolicyCondition) string {
+	parts := make([]string, 0, 4)
+	if condition.Threshold != nil {
+		operator, known := policyOperatorSymbols[condition.Operator]
+		if !known {
+			operator = condition.Operator
+		}
+		parts = append(parts, fmt.Sprintf("%s %s %g", condition.Metric, operator, *condition.Threshold))
+	} else {
+		parts = append(parts, fmt.Sprintf("%s events", condition.Metric))
+	}
+	if condition.Budget != nil {
+		if condition.Threshold != nil {
+			parts = append(parts, fmt.Sprintf("budget %g%% of samples", *condition.Budget))
+		} else {
+			parts = append(parts, fmt.Sprintf("budget %g events", *condition.Budget))
+		}
+	}
+	if condition.Interval != nil {
+		parts = append(parts, fmt.Sprintf("%s interval %s", condition.Interval.Type, condition.Interval.Duration))
+	}
+	if condition.GracePeriod != "" {
+		parts = append(parts, fmt.Sprintf("grace period %s", condition.GracePeriod))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func writeHooks(sb *strings.Builder, title string, hooks []PolicyHook) {
+	if len(hooks) == 0 {
+		fmt.Fprintf(sb, "%s none\n", title)
+		return
+	}
+	fmt.Fprintf(sb, "%s\n", title)
+	for _, hook := range hooks {
+		fmt.Fprintf(sb, "  - %s %s %s", hook.Type, hook.Value.HTTPRequest, hook.Value.URL)
+		if len(hook.Value.Headers) > 0 {
+			fmt.Fprintf(sb, " (%d header(s))", len(hook.Value.Headers))
+		}
+		sb.WriteString("\n")
+	}
+}
@@ -0,0 +1,26 @@
+package models_test
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/magneticio/forklift/models"
+)
+
+func TestExplainPolicy(t *testing.T) {
+	policyText, err := ioutil.ReadFile("../integrationtests/resources/validpolicy.json")
+	if err != nil {
+		t.Fatalf("cannot read policy: %v", err)
+	}
+	want, err := ioutil.ReadFile("resources/validpolicyexplained.txt")
+	if err != nil {
+		t.Fatalf("cannot read expected explanation: %v", err)
+	}
+	policy, err := models.ParsePolicyDocument(string(policyText))
+	if err != nil {
+		t.Fatalf("cannot parse policy: %v", err)
+	}
+	if got := models.ExplainPolicy(*policy); got != string(want) {
+		t.Errorf("ExplainPolicy() = %v, want %v", got, string(want))
+	}
+}
@@ -0,0 +1,55 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// HookEventSuccess - event triggering onSuccess hooks
+	HookEventSuccess = "success"
+	// HookEventFailure - event triggering onFailure hooks
+	HookEventFailure = "failure"
+)
+
+// HookTestResult - result of sending a policy hook request
+type HookTestResult struct {
+	Event      string
+	Method     string
+	URL        string
+	StatusCode int
+	Status     string
+	Latency    time.Duration
+	Body       string
+	Error      string
+}
+
+func (result HookTestResult) String() string {
+	if result.StatusCode == 0 {
+		return fmt.Sprintf("%s %s failed: %s", result.Method, result.URL, result.Error)
+	}
+	return fmt.Sprintf("%s %s -> %s in %s", result.Method, result.URL, result.Status, result.Latency.Round(time.Millisecond))
+}
+
+// HookTestPayload - sample payload sent with tested policy hooks
+type HookTestPayload struct {
+	Test      bool               `json:"test"`
+	PolicyID  uint64             `json:"policyId"`
+	Event     string             `json:"event"`
+	Service   ReleasePlanService `json:"service"`
+	Timestamp string             `json:"timestamp"`
+}
+
+// NewHookTestPayload - creates sample payload for tested policy hooks
+func NewHookTestPayload(policyID uint64, event string) HookTestPayload {
+	return HookTestPayload{
+		Test:     true,
+		PolicyID: policyID,
+		Event:    event,
+		Service: ReleasePlanService{
+			Name:    "sample-service",
+			Version: "1.0.0",
+		},
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+	}
+}
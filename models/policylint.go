@@ -0,0 +1,142 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// PolicyConditionOperators - operators supported in policy conditions
+var PolicyConditionOperators = []string{"eq", "ne", "gt", "ge", "lt", "le"}
+
+// PolicyLintIssue - problem found in a policy definition
+type PolicyLintIssue struct {
+	Pointer string `yaml:"pointer"`
+	Message string `yaml:"message"`
+}
+
+func (issue PolicyLintIssue) String() string {
+	pointer := issue.Pointer
+	if pointer == "" {
+		pointer = "/"
+	}
+	return fmt.Sprintf("%s: %s", pointer, issue.Message)
+}
+
+// LintPolicy - checks policy definition and reports all problems found
+// problem locations are JSON pointers to the invalid values
+func LintPolicy(policyText string) []PolicyLintIssue {
+	policy, err := ParsePolicyDocument(policyText)
+	if err != nil {
+		return []PolicyLintIssue{{Message: err.Error()}}
+	}
+
+	linter := &policyLinter{
+		issues:  make([]PolicyLintIssue, 0),
+		metrics: make(map[string]bool),
+	}
+	linter.lint(*policy)
+
+	return linter.issues
+}
+
+type policyLinter struct {
+	issues  []PolicyLintIssue
+	metrics map[string]bool
+}
+
+func (l *policyLinter) report(pointer string, format string, args ...interface{}) {
+	l.issues = append(l.issues, PolicyLintIssue{
+		Pointer: pointer,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+func (l *policyLinter) lint(policy PolicyDocument) {
+	switch policy.Type {
+	case ReleasePolicyType:
+		if len(policy.Steps) == 0 {
+			l.report("/steps", "release policy must contain at least one step")
+		}
+	case ValidationPolicyType:
+	default:
+		l.report("/type", "unknown policy type '%s'", policy.Type)
+	}
+
+	for i, metric := range policy.Metrics {
+		pointer := fmt.Sprintf("/metrics/%d", i)
+		if metric.Name == "" {
+			l.report(pointer+"/name", "metric name must not be empty")
+			continue
+		}
+		if l.metrics[metric.Name] {
+			l.report(pointer+"/name", "duplicate metric name '%s'", metric.Name)
+		}
+		l.metrics[metric.Name] = true
+	}
+
+	for i, step := range policy.Steps {
+		l.lintStep(fmt.Sprintf("/steps/%d", i), step)
+	}
+
+	for i, condition := range policy.Conditions {
+		l.lintCondition(fmt.Sprintf("/conditions/%d", i), condition)
+	}
+}
+
+func (l *policyLinter) lintStep(pointer string, step PolicyStep) {
+	l.lintWeight(pointer+"/source/weight", step.Source.Weight)
+	l.lintWeight(pointer+"/target/weight", step.Target.Weight)
+	if step.Source.Weight+step.Target.Weight != 100 {
+		l.report(pointer, "source weight %d and target weight %d must sum up to 100", step.Source.Weight, step.Target.Weight)
+	}
+	if step.EndAfter.MaxDuration == "" {
+		l.report(pointer+"/endAfter/maxDuration", "max duration must be defined")
+	} else {
+		l.lintDuration(pointer+"/endAfter/maxDuration", step.EndAfter.MaxDuration)
+	}
+	for i, condition := range step.Conditions {
+		l.lintCondition(fmt.Sprintf("%s/conditions/%d", pointer, i), condition)
+	}
+}
+
+func (l *policyLinter) lintCondition(pointer string, condition PolicyCondition) {
+	if !l.metrics[condition.Metric] {
+		l.report(pointer+"/metric", "metric '%s' is not defined in metrics", condition.Metric)
+	}
+	if condition.GracePeriod != "" {
+		l.lintDuration(pointer+"/gracePeriod", condition.GracePeriod)
+	}
+	if condition.Interval != nil {
+		l.lintDuration(pointer+"/interval/duration", condition.Interval.Duration)
+	}
+	if condition.Operator != "" && !isPolicyConditionOperator(condition.Operator) {
+		l.report(pointer+"/operator", "unknown operator '%s', expected one of %v", condition.Operator, PolicyConditionOperators)
+	}
+	if condition.Operator != "" && condition.Threshold == nil {
+		l.report(pointer+"/threshold", "threshold must be defined when operator is defined")
+	}
+	if condition.Operator == "" && condition.Threshold != nil {
+		l.report(pointer+"/operator", "operator must be defined when threshold is defined")
+	}
+}
+
+func (l *policyLinter) lintWeight(pointer string, weight int64) {
+	if weight < 0 || weight > 100 {
+		l.report(pointer, "weight %d must be between 0 and 100", weight)
+	}
+}
+
+func (l *policyLinter) lintDuration(pointer string, duration string) {
+	if _, err := time.ParseDuration(duration); err != nil {
+		l.report(pointer, "invalid duration '%s'", duration)
+	}
+}
+
+func isPolicyConditionOperator(operator string) bool {
+	for _, supportedOperator := range PolicyConditionOperators {
+		if operator == supportedOperator {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,54 @@
+package models_test
+
+import (
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	"github.com/magneticio/forklift/models"
+)
+
+func TestLintPolicy(t *testing.T) {
+	tests := []struct {
+		name       string
+		policyPath string
+		want       []models.PolicyLintIssue
+	}{
+		{
+			name:       "valid policy",
+			policyPath: "../integrationtests/resources/validpolicy.json",
+			want:       []models.PolicyLintIssue{},
+		},
+		{
+			name:       "invalid policy",
+			policyPath: "resources/invalidpolicy.json",
+			want: []models.PolicyLintIssue{
+				{Pointer: "/metrics/1/name", Message: "duplicate metric name 'Health'"},
+				{Pointer: "/steps/0/target/weight", Message: "weight 120 must be between 0 and 100"},
+				{Pointer: "/steps/0", Message: "source weight 90 and target weight 120 must sum up to 100"},
+				{Pointer: "/steps/0/endAfter/maxDuration", Message: "invalid duration '1 minute'"},
+				{Pointer: "/steps/0/conditions/0/operator", Message: "unknown operator '>=', expected one of [eq ne gt ge lt le]"},
+				{Pointer: "/steps/0/conditions/1/metric", Message: "metric 'latency' is not defined in metrics"},
+				{Pointer: "/steps/0/conditions/1/gracePeriod", Message: "invalid duration 'soon'"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policyText, err := ioutil.ReadFile(tt.policyPath)
+			if err != nil {
+				t.Fatalf("cannot read policy: %v", err)
+			}
+			if got := models.LintPolicy(string(policyText)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LintPolicy() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLintPolicyWithMalformedPolicy(t *testing.T) {
+	got := models.LintPolicy(`{"type": "release", "steps": "none"}`)
+	if len(got) != 1 || got[0].Pointer != "" {
+		t.Errorf("LintPolicy() = %v, want single issue for the whole document", got)
+	}
+}
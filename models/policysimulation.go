@@ -0,0 +1,273 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// PolicySimulationSucceeded - all steps finished without violating any condition
+	PolicySimulationSucceeded = "succeeded"
+	// PolicySimulationRolledBack - a condition has been violated and release would be rolled back
+	PolicySimulationRolledBack = "rolled back"
+	// PolicySimulationIncomplete - metric series ended before all steps finished
+	PolicySimulationIncomplete = "incomplete"
+)
+
+// PolicySimulationEvent - single entry of the simulated release timeline
+type PolicySimulationEvent struct {
+	Time    time.Duration
+	Step    int
+	Message string
+}
+
+func (event PolicySimulationEvent) String() string {
+	return fmt.Sprintf("%10s  step %d  %s", event.Time, event.Step, event.Message)
+}
+
+// PolicySimulation - result of replaying a release policy over a metric series
+type PolicySimulation struct {
+	Events  []PolicySimulationEvent
+	Outcome string
+}
+
+// SimulatePolicy - replays release policy steps over metric series
+// Steps are executed one after another, each one for its max duration.
+// Condition is evaluated at every sample of its metric after the grace period of the step
+// using samples from its rolling interval, or from the whole step if interval is not defined.
+// Condition with threshold passes if the percentage of samples satisfying the threshold
+// is not lower than the budget (100 by default).
+// Condition without threshold counts events, it passes if the sum of metric values
+// does not exceed the budget (0 by default).
+// The first failed condition rolls the release back.
+func SimulatePolicy(policy PolicyDocument, series MetricSeries) (*PolicySimulation, error) {
+	if policy.Type != ReleasePolicyType {
+		return nil, fmt.Errorf("only release policies can be simulated, policy type is '%s'", policy.Type)
+	}
+	if len(policy.Steps) == 0 {
+		return nil, fmt.Errorf("policy has no steps to simulate")
+	}
+	if len(series.Times) == 0 {
+		return nil, fmt.Errorf("metric series is empty")
+	}
+
+	simulator := &policySimulator{
+		series: series,
+		events: make([]PolicySimulationEvent, 0),
+	}
+	outcome, err := simulator.run(policy)
+	if err != nil {
+		return nil, err
+	}
+	return &PolicySimulation{
+		Events:  simulator.events,
+		Outcome: outcome,
+	}, nil
+}
+
+type policySimulator struct {
+	series MetricSeries
+	events []PolicySimulationEvent
+}
+
+type simulatedCondition struct {
+	condition   PolicyCondition
+	gracePeriod time.Duration
+	interval    time.Duration
+	passing     *bool
+}
+
+func (s *policySimulator) record(at time.Duration, step int, format string, args ...interface{}) {
+	s.events = append(s.events, PolicySimulationEvent{
+		Time:    at,
+		Step:    step,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+func (s *policySimulator) run(policy PolicyDocument) (string, error) {
+	seriesEnd := s.getSeriesEnd()
+	stepStart := time.Duration(0)
+	for i, step := range policy.Steps {
+		stepNumber := i + 1
+		stepDuration, err := time.ParseDuration(step.EndAfter.MaxDuration)
+		if err != nil {
+			return "", fmt.Errorf("step %d: invalid max duration '%s'", stepNumber, step.EndAfter.MaxDuration)
+		}
+		stepEnd := stepStart + stepDuration
+
+		conditions := make([]*simulatedCondition, 0, len(policy.Conditions)+len(step.Conditions))
+		for _, condition := range append(append([]PolicyCondition{}, policy.Conditions...), step.Conditions...) {
+			simulated, err := newSimulatedCondition(condition)
+			if err != nil {
+				return "", fmt.Errorf("step %d: %v", stepNumber, err)
+			}
+			if _, exists := s.series.Values[condition.Metric]; !exists {
+				s.record(stepStart, stepNumber, "no values of metric '%s' in series, condition %s is skipped", condition.Metric, describeCondition(condition))
+				continue
+			}
+			conditions = append(conditions, simulated)
+		}
+
+		s.record(stepStart, stepNumber, "started: source %d%%, target %d%%%s", step.Source.Weight, step.Target.Weight, describeTargetCondition(step.Target))
+
+		for sample, sampleTime := range s.series.Times {
+			if sampleTime < stepStart || sampleTime >= stepEnd {
+				continue
+			}
+			for _, condition := range conditions {
+				passing, summary, evaluated := s.evaluate(condition, stepStart, sample)
+				if !evaluated {
+					continue
+				}
+				if condition.passing == nil || *condition.passing != passing {
+					state := "passed"
+					if !passing {
+						state = "failed"
+					}
+					s.record(sampleTime, stepNumber, "condition %s %s: %s", describeCondition(condition.condition), state, summary)
+				}
+				condition.passing = &passing
+				if !passing {
+					s.record(sampleTime, stepNumber, "release rolled back%s", describeHooks(policy.OnFailure))
+					return PolicySimulationRolledBack, nil
+				}
+			}
+		}
+
+		if seriesEnd < stepEnd {
+			s.record(seriesEnd, stepNumber, "metric series ended %s before the step finishes", stepEnd-seriesEnd)
+			return PolicySimulationIncomplete, nil
+		}
+
+		s.record(stepEnd, stepNumber, "finished")
+		stepStart = stepEnd
+	}
+
+	s.record(stepStart, len(policy.Steps), "release succeeded%s", describeHooks(policy.OnSuccess))
+	return PolicySimulationSucceeded, nil
+}
+
+// getSeriesEnd - time until which series is considered to provide values
+// the last sample is assumed to cover the same period as the one before it
+func (s *policySimulator) getSeriesEnd() time.Duration {
+	times := s.series.Times
+	last := times[len(times)-1]
+	if len(times) < 2 {
+		return last
+	}
+	return last + (last - times[len(times)-2])
+}
+
+// evaluate - evaluates condition at the given sample
+// returns false as the last value if condition is not evaluated at this sample
+func (s *policySimulator) evaluate(condition *simulatedCondition, stepStart time.Duration, sample int) (bool, string, bool) {
+	values := s.series.Values[condition.condition.Metric]
+	sampleTime := s.series.Times[sample]
+	evaluationStart := stepStart + condition.gracePeriod
+	if sampleTime < evaluationStart || values[sample] == nil {
+		return false, "", false
+	}
+	windowStart := evaluationStart
+	if condition.interval > 0 && sampleTime-condition.interval > windowStart {
+		windowStart = sampleTime - condition.interval
+	}
+
+	total := 0
+	matching := 0
+	sum := 0.0
+	for i := sample; i >= 0 && s.series.Times[i] >= windowStart; i-- {
+		if values[i] == nil {
+			continue
+		}
+		total++
+		sum += *values[i]
+		if condition.condition.Threshold != nil && compareMetricValue(*values[i], condition.condition.Operator, *condition.condition.Threshold) {
+			matching++
+		}
+	}
+
+	if condition.condition.Threshold != nil {
+		budget := 100.0
+		if condition.condition.Budget != nil {
+			budget = *condition.condition.Budget
+		}
+		percentage := float64(matching) / float64(total) * 100
+		return percentage >= budget, fmt.Sprintf("%.1f%% of %d samples match, budget %g%%", percentage, total, budget), true
+	}
+
+	budget := 0.0
+	if condition.condition.Budget != nil {
+		budget = *condition.condition.Budget
+	}
+	return sum <= budget, fmt.Sprintf("%g events, budget %g", sum, budget), true
+}
+
+func newSimulatedCondition(condition PolicyCondition) (*simulatedCondition, error) {
+	simulated := &simulatedCondition{condition: condition}
+	if condition.Threshold != nil && !isPolicyConditionOperator(condition.Operator) {
+		return nil, fmt.Errorf("unknown operator '%s' in condition on metric '%s'", condition.Operator, condition.Metric)
+	}
+	if condition.GracePeriod != "" {
+		gracePeriod, err := time.ParseDuration(condition.GracePeriod)
+		if err != nil {
+			return nil, fmt.Errorf("invalid grace period '%s' in condition on metric '%s'", condition.GracePeriod, condition.Metric)
+		}
+		simulated.gracePeriod = gracePeriod
+	}
+	if condition.Interval != nil {
+		interval, err := time.ParseDuration(condition.Interval.Duration)
+		if err != nil {
+			return nil, fmt.Errorf("invalid interval duration '%s' in condition on metric '%s'", condition.Interval.Duration, condition.Metric)
+		}
+		simulated.interval = interval
+	}
+	return simulated, nil
+}
+
+func compareMetricValue(value float64, operator string, threshold float64) bool {
+	switch operator {
+	case "eq":
+		return value == threshold
+	case "ne":
+		return value != threshold
+	case "gt":
+		return value > threshold
+	case "ge":
+		return value >= threshold
+	case "lt":
+		return value < threshold
+	case "le":
+		return value <= threshold
+	}
+	return false
+}
+
+func describeCondition(condition PolicyCondition) string {
+	if condition.Threshold != nil {
+		return fmt.Sprintf("'%s %s %g'", condition.Metric, condition.Operator, *condition.Threshold)
+	}
+	return fmt.Sprintf("'%s'", condition.Metric)
+}
+
+func describeTargetCondition(target PolicyStepTarget) string {
+	if target.Condition == "" {
+		return ""
+	}
+	if target.ConditionStrength != nil {
+		return fmt.Sprintf(" (target condition '%s', strength %d%%)", target.Condition, *target.ConditionStrength)
+	}
+	return fmt.Sprintf(" (target condition '%s')", target.Condition)
+}
+
+func describeHooks(hooks []PolicyHook) string {
+	if len(hooks) == 0 {
+		return ""
+	}
+	requests := make([]string, len(hooks))
+	for i, hook := range hooks {
+		requests[i] = fmt.Sprintf("%s %s", hook.Value.HTTPRequest, hook.Value.URL)
+	}
+	return fmt.Sprintf(", hooks: %s", strings.Join(requests, ", "))
+}
@@ -0,0 +1,134 @@
+package models_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/magneticio/forklift/models"
+)
+
+const simulatedPolicy = `{
+  "type": "release",
+  "steps": [
+    {
+      "source": {"weight": 90},
+      "target": {"weight": 10},
+      "endAfter": {"maxDuration": "2m"}
+    },
+    {
+      "source": {"weight": 50},
+      "target": {"weight": 50},
+      "endAfter": {"maxDuration": "2m"}
+    }
+  ],
+  "conditions": [
+    {
+      "metric": "Health",
+      "budget": 70,
+      "interval": {"type": "rolling", "duration": "1m"},
+      "gracePeriod": "30s",
+      "threshold": 1,
+      "operator": "ge"
+    },
+    {
+      "metric": "restarts",
+      "budget": 1,
+      "interval": {"type": "rolling", "duration": "1m"}
+    }
+  ],
+  "metrics": [
+    {"name": "Health", "type": "TimeBased", "value": {"source": "k8s-deployment-health"}},
+    {"name": "restarts", "type": "EventBased", "value": {"source": "k8s-deployment-health", "type": "restarts"}}
+  ]
+}`
+
+func TestParseMetricSeries(t *testing.T) {
+	series, err := models.ParseMetricSeries("time,Health,restarts\n1m,1,\n30,0.5,2\n")
+	if err != nil {
+		t.Fatalf("ParseMetricSeries() error = %v", err)
+	}
+	if len(series.Times) != 2 || series.Times[0] != 30*time.Second || series.Times[1] != time.Minute {
+		t.Errorf("ParseMetricSeries() times = %v, want [30s 1m0s]", series.Times)
+	}
+	if *series.Values["Health"][0] != 0.5 || series.Values["restarts"][1] != nil {
+		t.Errorf("ParseMetricSeries() values are not sorted by time")
+	}
+
+	if _, err := models.ParseMetricSeries("Health,restarts\n1,0\n"); err == nil {
+		t.Errorf("ParseMetricSeries() should fail without time column")
+	}
+	if _, err := models.ParseMetricSeries("time,Health\n30s,high\n"); err == nil {
+		t.Errorf("ParseMetricSeries() should fail on invalid value")
+	}
+}
+
+func TestSimulatePolicy(t *testing.T) {
+	tests := []struct {
+		name        string
+		series      string
+		wantOutcome string
+		wantLast    string
+	}{
+		{
+			name: "healthy release",
+			series: `time,Health,restarts
+0s,1,0
+30s,1,0
+1m,1,0
+1m30s,1,0
+2m,1,0
+2m30s,1,0
+3m,1,0
+3m30s,1,0`,
+			wantOutcome: models.PolicySimulationSucceeded,
+			wantLast:    "release succeeded",
+		},
+		{
+			name: "too many restarts",
+			series: `time,Health,restarts
+0s,1,0
+30s,1,0
+1m,1,0
+1m30s,1,0
+2m,1,0
+2m30s,1,1
+3m,1,1
+3m30s,1,0`,
+			wantOutcome: models.PolicySimulationRolledBack,
+			wantLast:    "release rolled back",
+		},
+		{
+			name: "series too short",
+			series: `time,Health,restarts
+0s,1,0
+30s,1,0
+1m,1,0`,
+			wantOutcome: models.PolicySimulationIncomplete,
+			wantLast:    "metric series ended 30s before the step finishes",
+		},
+	}
+	policy, err := models.ParsePolicyDocument(simulatedPolicy)
+	if err != nil {
+		t.Fatalf("cannot parse policy: %v", err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			series, err := models.ParseMetricSeries(tt.series)
+			if err != nil {
+				t.Fatalf("cannot parse metric series: %v", err)
+			}
+			simulation, err := models.SimulatePolicy(*policy, *series)
+			if err != nil {
+				t.Fatalf("SimulatePolicy() error = %v", err)
+			}
+			if simulation.Outcome != tt.wantOutcome {
+				t.Errorf("SimulatePolicy() outcome = %v, want %v", simulation.Outcome, tt.wantOutcome)
+			}
+			last := simulation.Events[len(simulation.Events)-1]
+			if !strings.HasPrefix(last.Message, tt.wantLast) {
+				t.Errorf("SimulatePolicy() last event = %q, want %q", last.Message, tt.wantLast)
+			}
+		})
+	}
+}
@@ -0,0 +1,215 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+const (
+	// CanaryPolicyTemplate - traffic is shifted to the new version in equal steps
+	CanaryPolicyTemplate = "canary"
+	// BlueGreenPolicyTemplate - new version is checked without traffic and then receives all traffic at once
+	BlueGreenPolicyTemplate = "blue-green"
+	// DarkLaunchPolicyTemplate - new version receives only requests with a cookie before all traffic is shifted
+	DarkLaunchPolicyTemplate = "dark-launch"
+	// ValidationPolicyTemplate - validation policy with conditions only
+	ValidationPolicyTemplate = "validation"
+
+	defaultTemplateMetricSource = "k8s-deployment-health"
+	defaultTemplateBudget       = 90
+	defaultTemplateInterval     = "5m0s"
+	defaultTemplateGracePeriod  = "1m0s"
+	darkLaunchCondition         = "cookie: vamp exists"
+)
+
+// PolicyTemplates - names of built-in policy templates
+var PolicyTemplates = []string{CanaryPolicyTemplate, BlueGreenPolicyTemplate, DarkLaunchPolicyTemplate, ValidationPolicyTemplate}
+
+// policyOperatorsBySymbol - operators ordered so that two character symbols are matched first
+var policyOperatorsBySymbol = []struct {
+	symbol   string
+	operator string
+}{
+	{">=", "ge"},
+	{"<=", "le"},
+	{"==", "eq"},
+	{"!=", "ne"},
+	{">", "gt"},
+	{"<", "lt"},
+}
+
+// PolicyTemplateMetric - metric with a threshold condition added to generated policy
+type PolicyTemplateMetric struct {
+	Name      string
+	Operator  string
+	Threshold float64
+}
+
+// PolicyTemplateParameters - parameters of policy templates
+type PolicyTemplateParameters struct {
+	Steps        int
+	StepDuration string
+	Metrics      []PolicyTemplateMetric
+}
+
+// ParsePolicyTemplateMetric - parses metric condition like Health>=1
+func ParsePolicyTemplateMetric(text string) (*PolicyTemplateMetric, error) {
+	for _, candidate := range policyOperatorsBySymbol {
+		index := strings.Index(text, candidate.symbol)
+		if index < 0 {
+			continue
+		}
+		name := strings.TrimSpace(text[:index])
+		thresholdText := strings.TrimSpace(text[index+len(candidate.symbol):])
+		if name == "" {
+			return nil, fmt.Errorf("metric name missing in '%s'", text)
+		}
+		threshold, err := strconv.ParseFloat(thresholdText, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid threshold '%s' in '%s'", thresholdText, text)
+		}
+		return &PolicyTemplateMetric{
+			Name:      name,
+			Operator:  candidate.operator,
+			Threshold: threshold,
+		}, nil
+	}
+	return nil, fmt.Errorf("metric condition '%s' must have form <metric><operator><threshold>, e.g. Health>=1", text)
+}
+
+// GeneratePolicy - generates policy from a built-in template
+func GeneratePolicy(templateName string, parameters PolicyTemplateParameters) (*PolicyDocument, error) {
+	if parameters.Steps < 1 {
+		return nil, fmt.Errorf("number of steps must be at least 1")
+	}
+	if len(parameters.Metrics) == 0 {
+		return nil, fmt.Errorf("at least one metric condition must be provided")
+	}
+
+	policy := &PolicyDocument{
+		Type:    ReleasePolicyType,
+		Version: 1,
+		Metrics: newTemplateMetrics(parameters.Metrics),
+	}
+
+	switch templateName {
+	case CanaryPolicyTemplate:
+		for i := 1; i <= parameters.Steps; i++ {
+			targetWeight := int64(i * 100 / parameters.Steps)
+			policy.Steps = append(policy.Steps, newTemplateStep(100-targetWeight, targetWeight, parameters.StepDuration))
+		}
+	case BlueGreenPolicyTemplate:
+		policy.Steps = []PolicyStep{
+			newTemplateStep(100, 0, parameters.StepDuration),
+			newTemplateStep(0, 100, parameters.StepDuration),
+		}
+	case DarkLaunchPolicyTemplate:
+		for i := 1; i <= parameters.Steps; i++ {
+			step := newTemplateStep(100, 0, parameters.StepDuration)
+			conditionStrength := int64(i * 100 / (parameters.Steps + 1))
+			step.Target.Condition = darkLaunchCondition
+			step.Target.ConditionStrength = &conditionStrength
+			policy.Steps = append(policy.Steps, step)
+		}
+		policy.Steps = append(policy.Steps, newTemplateStep(0, 100, parameters.StepDuration))
+	case ValidationPolicyTemplate:
+		policy.Type = ValidationPolicyType
+		policy.Conditions = newTemplateConditions(parameters.Metrics)
+	default:
+		return nil, fmt.Errorf("unknown policy template '%s', expected one of %v", templateName, PolicyTemplates)
+	}
+
+	// release policies gate promotion of every step with its own conditions like the stored release policies do
+	for i := range policy.Steps {
+		policy.Steps[i].Conditions = newTemplateConditions(parameters.Metrics)
+	}
+
+	return policy, nil
+}
+
+// RenderPolicyTemplate - renders user defined policy template
+// template is a text/template with parameters available as .Steps, .StepDuration and .Metrics
+// functions seq N (1..N), weight I N (I*100/N) and last I N are available
+func RenderPolicyTemplate(templateText string, parameters PolicyTemplateParameters) (string, error) {
+	policyTemplate, err := template.New("policy").Funcs(template.FuncMap{
+		"seq": func(n int) []int {
+			sequence := make([]int, n)
+			for i := range sequence {
+				sequence[i] = i + 1
+			}
+			return sequence
+		},
+		"weight": func(i, n int) int {
+			return i * 100 / n
+		},
+		"last": func(i, n int) bool {
+			return i == n
+		},
+	}).Parse(templateText)
+	if err != nil {
+		return "", fmt.Errorf("cannot parse policy template: %v", err)
+	}
+	var output bytes.Buffer
+	if err := policyTemplate.Execute(&output, parameters); err != nil {
+		return "", fmt.Errorf("cannot render policy template: %v", err)
+	}
+	return output.String(), nil
+}
+
+// MarshalPolicyDocument - serializes policy to json text
+func MarshalPolicyDocument(policy PolicyDocument) (string, error) {
+	policyBytes, err := json.Marshal(policy)
+	if err != nil {
+		return "", fmt.Errorf("cannot serialize policy: %v", err)
+	}
+	return string(policyBytes), nil
+}
+
+func newTemplateStep(sourceWeight, targetWeight int64, duration string) PolicyStep {
+	return PolicyStep{
+		Source:   PolicyStepSource{Weight: sourceWeight},
+		Target:   PolicyStepTarget{Weight: targetWeight},
+		EndAfter: PolicyStepEndAfter{MaxDuration: duration},
+	}
+}
+
+func newTemplateMetrics(metrics []PolicyTemplateMetric) []PolicyMetric {
+	names := make([]string, 0, len(metrics))
+	seen := make(map[string]bool, len(metrics))
+	for _, metric := range metrics {
+		if !seen[metric.Name] {
+			seen[metric.Name] = true
+			names = append(names, metric.Name)
+		}
+	}
+	policyMetrics := make([]PolicyMetric, len(names))
+	for i, name := range names {
+		policyMetrics[i] = PolicyMetric{
+			Name:  name,
+			Type:  "TimeBased",
+			Value: PolicyMetricValue{Source: defaultTemplateMetricSource},
+		}
+	}
+	return policyMetrics
+}
+
+func newTemplateConditions(metrics []PolicyTemplateMetric) []PolicyCondition {
+	conditions := make([]PolicyCondition, len(metrics))
+	for i, metric := range metrics {
+		budget := float64(defaultTemplateBudget)
+		threshold := metric.Threshold
+		conditions[i] = PolicyCondition{
+			Metric:      metric.Name,
+			Budget:      &budget,
+			Interval:    &PolicyInterval{Type: "rolling", Duration: defaultTemplateInterval},
+			GracePeriod: defaultTemplateGracePeriod,
+			Threshold:   &threshold,
+			Operator:    metric.Operator,
+		}
+	}
+	return conditions
+}
@@ -0,0 +1,89 @@
+package models_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/magneticio/forklift/models"
+)
+
+func TestParsePolicyTemplateMetric(t *testing.T) {
+	metric, err := models.ParsePolicyTemplateMetric("available replicas >= 80")
+	if err != nil {
+		t.Fatalf("ParsePolicyTemplateMetric() error = %v", err)
+	}
+	want := models.PolicyTemplateMetric{Name: "available replicas", Operator: "ge", Threshold: 80}
+	if !reflect.DeepEqual(*metric, want) {
+		t.Errorf("ParsePolicyTemplateMetric() = %v, want %v", *metric, want)
+	}
+	for _, text := range []string{"Health", ">=1", "Health>=high"} {
+		if _, err := models.ParsePolicyTemplateMetric(text); err == nil {
+			t.Errorf("ParsePolicyTemplateMetric(%q) should fail", text)
+		}
+	}
+}
+
+func TestGeneratePolicy(t *testing.T) {
+	parameters := models.PolicyTemplateParameters{
+		Steps:        4,
+		StepDuration: "2m0s",
+		Metrics:      []models.PolicyTemplateMetric{{Name: "Health", Operator: "ge", Threshold: 1}},
+	}
+	tests := []struct {
+		template      string
+		targetWeights []int64
+	}{
+		{template: models.CanaryPolicyTemplate, targetWeights: []int64{25, 50, 75, 100}},
+		{template: models.BlueGreenPolicyTemplate, targetWeights: []int64{0, 100}},
+		{template: models.DarkLaunchPolicyTemplate, targetWeights: []int64{0, 0, 0, 0, 100}},
+		{template: models.ValidationPolicyTemplate, targetWeights: []int64{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.template, func(t *testing.T) {
+			policy, err := models.GeneratePolicy(tt.template, parameters)
+			if err != nil {
+				t.Fatalf("GeneratePolicy() error = %v", err)
+			}
+			targetWeights := make([]int64, len(policy.Steps))
+			for i, step := range policy.Steps {
+				targetWeights[i] = step.Target.Weight
+			}
+			if !reflect.DeepEqual(targetWeights, tt.targetWeights) {
+				t.Errorf("GeneratePolicy() target weights = %v, want %v", targetWeights, tt.targetWeights)
+			}
+			for i, step := range policy.Steps {
+				if len(step.Conditions) != len(parameters.Metrics) || step.Conditions[0].Metric != "Health" {
+					t.Errorf("GeneratePolicy() step %d conditions = %+v, want a condition on Health", i+1, step.Conditions)
+				}
+			}
+			if wantConditions := len(policy.Steps) == 0; (len(policy.Conditions) > 0) != wantConditions {
+				t.Errorf("GeneratePolicy() policy conditions = %+v, want them only in validation policy", policy.Conditions)
+			}
+			policyText, err := models.MarshalPolicyDocument(*policy)
+			if err != nil {
+				t.Fatalf("MarshalPolicyDocument() error = %v", err)
+			}
+			if issues := models.LintPolicy(policyText); len(issues) > 0 {
+				t.Errorf("generated policy has problems: %v", issues)
+			}
+		})
+	}
+
+	if _, err := models.GeneratePolicy("rolling", parameters); err == nil {
+		t.Errorf("GeneratePolicy() should fail for unknown template")
+	}
+}
+
+func TestRenderPolicyTemplate(t *testing.T) {
+	templateText := `{{range $i := seq .Steps}}{{weight $i $.Steps}}{{if not (last $i $.Steps)}},{{end}}{{end}} {{(index .Metrics 0).Name}}`
+	got, err := models.RenderPolicyTemplate(templateText, models.PolicyTemplateParameters{
+		Steps:   3,
+		Metrics: []models.PolicyTemplateMetric{{Name: "Health", Operator: "ge", Threshold: 1}},
+	})
+	if err != nil {
+		t.Fatalf("RenderPolicyTemplate() error = %v", err)
+	}
+	if want := "33,66,100 Health"; got != want {
+		t.Errorf("RenderPolicyTemplate() = %q, want %q", got, want)
+	}
+}
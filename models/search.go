@@ -0,0 +1,318 @@
+package models
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	// SearchFieldDomain - ingress domain of a service
+	SearchFieldDomain = "domain"
+	// SearchFieldPath - ingress path of a service
+	SearchFieldPath = "path"
+	// SearchFieldTLSSecret - TLS secret name of a service ingress
+	SearchFieldTLSSecret = "tls-secret"
+	// SearchFieldLabel - Kubernetes label of a service as key=value
+	SearchFieldLabel = "label"
+	// SearchFieldNamespace - Kubernetes namespace of an application or a service
+	SearchFieldNamespace = "namespace"
+	// SearchFieldNatsChannel - NATS channel or optimiser NATS channel of a cluster
+	SearchFieldNatsChannel = "nats-channel"
+	// SearchFieldMetric - metric name or source used by a policy
+	SearchFieldMetric = "metric"
+
+	// SearchKindCluster - search document of a cluster
+	SearchKindCluster = "cluster"
+	// SearchKindApplication - search document of an application
+	SearchKindApplication = "application"
+	// SearchKindService - search document of a service
+	SearchKindService = "service"
+	// SearchKindPolicy - search document of a policy
+	SearchKindPolicy = "policy"
+)
+
+// SearchFields - fields which can be used in search queries
+var SearchFields = []string{
+	SearchFieldDomain,
+	SearchFieldPath,
+	SearchFieldTLSSecret,
+	SearchFieldLabel,
+	SearchFieldNamespace,
+	SearchFieldNatsChannel,
+	SearchFieldMetric,
+}
+
+// SearchIndex - searchable documents of all resources of a project
+type SearchIndex struct {
+	BuiltAt   time.Time        `json:"built_at"`
+	Documents []SearchDocument `json:"documents"`
+}
+
+// SearchDocument - searchable field values of a single resource
+type SearchDocument struct {
+	Kind          string              `json:"kind"`
+	ClusterID     uint64              `json:"cluster_id,omitempty"`
+	ApplicationID uint64              `json:"application_id,omitempty"`
+	ServiceID     uint64              `json:"service_id,omitempty"`
+	PolicyID      uint64              `json:"policy_id,omitempty"`
+	Key           string              `json:"key"`
+	Fields        map[string][]string `json:"fields"`
+}
+
+// SearchResult - resource matching a search query
+type SearchResult struct {
+	Kind        string   `yaml:"kind"`
+	Coordinates string   `yaml:"coordinates"`
+	Key         string   `yaml:"key"`
+	Matches     []string `yaml:"matches"`
+}
+
+type searchTerm struct {
+	field string
+	value string
+}
+
+// SearchQuery - terms which all have to match a document
+type SearchQuery []searchTerm
+
+// ParseSearchQuery - parses space separated terms like domain:shop.example.com label:app=checkout
+// values of field terms match whole field values and can contain * wildcards,
+// terms without a field match any field value containing them,
+// double quotes keep phrases like "available replicas" or metric:"available replicas" in one term
+func ParseSearchQuery(text string) (SearchQuery, error) {
+	termTexts, err := splitSearchTerms(text)
+	if err != nil {
+		return nil, err
+	}
+	query := make(SearchQuery, 0)
+	for _, termText := range termTexts {
+		term := searchTerm{value: strings.ToLower(termText)}
+		if separator := strings.Index(termText, ":"); separator >= 0 {
+			term.field = strings.ToLower(termText[:separator])
+			term.value = strings.ToLower(termText[separator+1:])
+			if !isSearchField(term.field) {
+				return nil, fmt.Errorf("unknown search field '%s', expected one of %v", term.field, SearchFields)
+			}
+		}
+		if term.value == "" {
+			return nil, fmt.Errorf("search term '%s' has no value", termText)
+		}
+		query = append(query, term)
+	}
+	if len(query) == 0 {
+		return nil, fmt.Errorf("search query must not be empty")
+	}
+	return query, nil
+}
+
+// splitSearchTerms - splits query on whitespace outside of double quotes, quotes are removed from terms
+func splitSearchTerms(text string) ([]string, error) {
+	terms := make([]string, 0)
+	var term strings.Builder
+	inTerm, quoted := false, false
+	for _, r := range text {
+		switch {
+		case r == '"':
+			inTerm, quoted = true, !quoted
+		case unicode.IsSpace(r) && !quoted:
+			if inTerm {
+				terms = append(terms, term.String())
+				term.Reset()
+				inTerm = false
+			}
+		default:
+			term.WriteRune(r)
+			inTerm = true
+		}
+	}
+	if quoted {
+		return nil, fmt.Errorf("search query has unterminated quote")
+	}
+	if inTerm {
+		terms = append(terms, term.String())
+	}
+	return terms, nil
+}
+
+// Search - finds documents matching all terms of the query
+func (si SearchIndex) Search(query SearchQuery) []SearchResult {
+	results := make([]SearchResult, 0)
+	for _, document := range si.Documents {
+		matches, matched := query.match(document)
+		if !matched {
+			continue
+		}
+		results = append(results, SearchResult{
+			Kind:        document.Kind,
+			Coordinates: document.Coordinates(),
+			Key:         document.Key,
+			Matches:     matches,
+		})
+	}
+	return results
+}
+
+// Coordinates - describes location of the resource like cluster 7 / application 6 / service 5
+func (sd SearchDocument) Coordinates() string {
+	switch sd.Kind {
+	case SearchKindCluster:
+		return fmt.Sprintf("cluster %d", sd.ClusterID)
+	case SearchKindApplication:
+		return fmt.Sprintf("cluster %d / application %d", sd.ClusterID, sd.ApplicationID)
+	case SearchKindService:
+		return fmt.Sprintf("cluster %d / application %d / service %d", sd.ClusterID, sd.ApplicationID, sd.ServiceID)
+	case SearchKindPolicy:
+		return fmt.Sprintf("policy %d", sd.PolicyID)
+	}
+	return sd.Key
+}
+
+func (sq SearchQuery) match(document SearchDocument) ([]string, bool) {
+	matches := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, term := range sq {
+		termMatched := false
+		for _, field := range getSortedSearchFields(document) {
+			if term.field != "" && term.field != field {
+				continue
+			}
+			for _, value := range document.Fields[field] {
+				if !term.matches(value) {
+					continue
+				}
+				termMatched = true
+				match := field + ": " + value
+				if !seen[match] {
+					seen[match] = true
+					matches = append(matches, match)
+				}
+			}
+		}
+		if !termMatched {
+			return nil, false
+		}
+	}
+	return matches, true
+}
+
+func (st searchTerm) matches(value string) bool {
+	value = strings.ToLower(value)
+	if st.field == "" {
+		return strings.Contains(value, st.value)
+	}
+	if strings.Contains(st.value, "*") {
+		matched, err := path.Match(st.value, value)
+		return err == nil && matched
+	}
+	return value == st.value
+}
+
+// SortSearchDocuments - sorts documents by kind and ids so that search results are stable
+func SortSearchDocuments(documents []SearchDocument) {
+	kindOrder := map[string]int{
+		SearchKindCluster:     0,
+		SearchKindApplication: 1,
+		SearchKindService:     2,
+		SearchKindPolicy:      3,
+	}
+	sort.SliceStable(documents, func(i, j int) bool {
+		a, b := documents[i], documents[j]
+		if a.ClusterID != b.ClusterID {
+			return a.ClusterID < b.ClusterID
+		}
+		if a.ApplicationID != b.ApplicationID {
+			return a.ApplicationID < b.ApplicationID
+		}
+		if kindOrder[a.Kind] != kindOrder[b.Kind] {
+			return kindOrder[a.Kind] < kindOrder[b.Kind]
+		}
+		if a.ServiceID != b.ServiceID {
+			return a.ServiceID < b.ServiceID
+		}
+		return a.PolicyID < b.PolicyID
+	})
+}
+
+func getSortedSearchFields(document SearchDocument) []string {
+	fields := make([]string, 0, len(document.Fields))
+	for field := range document.Fields {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+func isSearchField(field string) bool {
+	for _, searchField := range SearchFields {
+		if field == searchField {
+			return true
+		}
+	}
+	return false
+}
+
+// NewServiceSearchDocument - creates search document of a service config
+func NewServiceSearchDocument(clusterID, applicationID, serviceID uint64, key string, serviceConfig ServiceConfig) SearchDocument {
+	fields := make(map[string][]string)
+	fields[SearchFieldNamespace] = []string{serviceConfig.K8SNamespace}
+	labelKeys := make([]string, 0, len(serviceConfig.K8sLabels))
+	for labelKey := range serviceConfig.K8sLabels {
+		labelKeys = append(labelKeys, labelKey)
+	}
+	sort.Strings(labelKeys)
+	for _, labelKey := range labelKeys {
+		fields[SearchFieldLabel] = append(fields[SearchFieldLabel], labelKey+"="+serviceConfig.K8sLabels[labelKey])
+	}
+	for _, ingressRule := range serviceConfig.IngressRules {
+		if ingressRule == nil {
+			continue
+		}
+		fields[SearchFieldDomain] = append(fields[SearchFieldDomain], ingressRule.Domain)
+		fields[SearchFieldPath] = append(fields[SearchFieldPath], ingressRule.Path)
+		if ingressRule.TLSSecretName != "" {
+			fields[SearchFieldTLSSecret] = append(fields[SearchFieldTLSSecret], ingressRule.TLSSecretName)
+		}
+	}
+	return SearchDocument{
+		Kind:          SearchKindService,
+		ClusterID:     clusterID,
+		ApplicationID: applicationID,
+		ServiceID:     serviceID,
+		Key:           key,
+		Fields:        fields,
+	}
+}
+
+// NewPolicySearchDocument - creates search document of a policy with names and sources of its metrics
+func NewPolicySearchDocument(policyID uint64, key string, policy PolicyDocument) SearchDocument {
+	metrics := make([]string, 0)
+	seen := make(map[string]bool)
+	addMetric := func(metric string) {
+		if metric != "" && !seen[metric] {
+			seen[metric] = true
+			metrics = append(metrics, metric)
+		}
+	}
+	for _, metric := range policy.Metrics {
+		addMetric(metric.Name)
+		addMetric(metric.Value.Source)
+	}
+	for _, condition := range policy.Conditions {
+		addMetric(condition.Metric)
+	}
+	for _, step := range policy.Steps {
+		for _, condition := range step.Conditions {
+			addMetric(condition.Metric)
+		}
+	}
+	return SearchDocument{
+		Kind:     SearchKindPolicy,
+		PolicyID: policyID,
+		Key:      key,
+		Fields:   map[string][]string{SearchFieldMetric: metrics},
+	}
+}
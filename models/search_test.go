@@ -0,0 +1,108 @@
+package models_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/magneticio/forklift/models"
+)
+
+func TestSearchIndexSearch(t *testing.T) {
+	port := int64(80)
+	applicationID, serviceID := uint64(6), uint64(5)
+	serviceConfig := models.ServiceConfig{
+		ApplicationID: &applicationID,
+		ServiceID:     &serviceID,
+		K8SNamespace:  "shop",
+		K8sLabels:     map[string]string{"app": "checkout", "tier": "web"},
+		IngressRules: []*models.ServiceConfigIngressRule{
+			{Domain: "shop.example.com", Path: "/checkout", TLSSecretName: "shop-tls", Port: &port},
+		},
+	}
+	index := models.SearchIndex{
+		Documents: []models.SearchDocument{
+			{
+				Kind:      models.SearchKindCluster,
+				ClusterID: 7,
+				Key:       "clusters/7/release-agent-config",
+				Fields:    map[string][]string{models.SearchFieldNatsChannel: {"shop-channel"}},
+			},
+			models.NewServiceSearchDocument(7, 6, 5, "clusters/7/applications/6/service-configs/5", serviceConfig),
+			models.NewPolicySearchDocument(1, "policies/1", models.PolicyDocument{
+				Metrics: []models.PolicyMetric{
+					{Name: "Health", Value: models.PolicyMetricValue{Source: "k8s-deployment-health"}},
+					{Name: "Available Replicas", Value: models.PolicyMetricValue{Source: "k8s-deployment-replicas"}},
+				},
+			}),
+		},
+	}
+
+	tests := []struct {
+		query string
+		want  []models.SearchResult
+	}{
+		{
+			query: "domain:shop.example.com",
+			want: []models.SearchResult{{
+				Kind:        models.SearchKindService,
+				Coordinates: "cluster 7 / application 6 / service 5",
+				Key:         "clusters/7/applications/6/service-configs/5",
+				Matches:     []string{"domain: shop.example.com"},
+			}},
+		},
+		{
+			query: "label:app=checkout namespace:sh*",
+			want: []models.SearchResult{{
+				Kind:        models.SearchKindService,
+				Coordinates: "cluster 7 / application 6 / service 5",
+				Key:         "clusters/7/applications/6/service-configs/5",
+				Matches:     []string{"label: app=checkout", "namespace: shop"},
+			}},
+		},
+		{
+			query: "metric:health",
+			want: []models.SearchResult{{
+				Kind:        models.SearchKindPolicy,
+				Coordinates: "policy 1",
+				Key:         "policies/1",
+				Matches:     []string{"metric: Health"},
+			}},
+		},
+		{
+			query: `metric:"available replicas"`,
+			want: []models.SearchResult{{
+				Kind:        models.SearchKindPolicy,
+				Coordinates: "policy 1",
+				Key:         "policies/1",
+				Matches:     []string{"metric: Available Replicas"},
+			}},
+		},
+		{
+			query: `"replicas available"`,
+			want:  []models.SearchResult{},
+		},
+		{
+			query: "label:app=payments",
+			want:  []models.SearchResult{},
+		},
+	}
+	for _, test := range tests {
+		query, err := models.ParseSearchQuery(test.query)
+		if err != nil {
+			t.Fatalf("ParseSearchQuery(%q) error = %v", test.query, err)
+		}
+		if got := index.Search(query); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Search(%q) = %v, want %v", test.query, got, test.want)
+		}
+	}
+
+	query, _ := models.ParseSearchQuery("shop")
+	if got := index.Search(query); len(got) != 2 {
+		t.Errorf("Search(shop) = %v, want cluster and service", got)
+	}
+	for _, invalid := range []string{"", "owner:team", "domain:", `""`, `metric:"available replicas`} {
+		if _, err := models.ParseSearchQuery(invalid); err == nil {
+			t.Errorf("ParseSearchQuery(%q) should fail", invalid)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// MaskedHeaderValue - replaces header values in shown policies
+const MaskedHeaderValue = "******"
+
+const projectSecretScope = "project/"
+
+var secretReferencePattern = regexp.MustCompile(`\$\{secret:([^}]*)\}`)
+
+var headerSchemePattern = regexp.MustCompile(`^\s*([A-Za-z][A-Za-z0-9-]*\s+)?$`)
+
+var secretNameSegmentPattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// ValidateSecretName - checks that secret name is a slash separated path like hooks/slack
+func ValidateSecretName(secretName string) error {
+	if secretName == "" {
+		return fmt.Errorf("secret name must not be empty")
+	}
+	for _, segment := range strings.Split(secretName, "/") {
+		if !secretNameSegmentPattern.MatchString(segment) || segment == "." || segment == ".." {
+			return fmt.Errorf("invalid secret name '%s', expected slash separated path of letters, digits, '.', '_' and '-'", secretName)
+		}
+	}
+	return nil
+}
+
+// GetSecretReferenceName - gets name of a project secret from reference like project/hooks/slack
+func GetSecretReferenceName(reference string) (string, error) {
+	if !strings.HasPrefix(reference, projectSecretScope) {
+		return "", fmt.Errorf("secret reference '%s' must start with '%s'", reference, projectSecretScope)
+	}
+	secretName := strings.TrimPrefix(reference, projectSecretScope)
+	if err := ValidateSecretName(secretName); err != nil {
+		return "", err
+	}
+	return secretName, nil
+}
+
+// FormatSecretReference - wraps secret reference like project/hooks/slack as ${secret:project/hooks/slack}
+func FormatSecretReference(reference string) string {
+	return fmt.Sprintf("${secret:%s}", reference)
+}
+
+// FindSecretReferences - finds secret references like ${secret:project/hooks/slack} in policy hook headers
+// each reference is returned once without the ${secret:} wrapper
+func FindSecretReferences(policy PolicyDocument) []string {
+	headers := make([]string, 0)
+	for _, hook := range append(append([]PolicyHook{}, policy.OnSuccess...), policy.OnFailure...) {
+		headers = append(headers, hook.Value.Headers...)
+	}
+	return FindTextSecretReferences(strings.Join(headers, "\n"))
+}
+
+// FindTextSecretReferences - finds secret references in text
+// each reference is returned once without the ${secret:} wrapper
+func FindTextSecretReferences(text string) []string {
+	references := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, match := range secretReferencePattern.FindAllStringSubmatch(text, -1) {
+		if !seen[match[1]] {
+			seen[match[1]] = true
+			references = append(references, match[1])
+		}
+	}
+	return references
+}
+
+// MaskPolicyHeaders - masks values of hook headers which do not consist only of secret references
+// the policy is parsed token by token and serialized again so that order and all fields are kept
+// while only strings of onSuccess and onFailure hook headers are masked
+func MaskPolicyHeaders(policyText string) (string, error) {
+	decoder := json.NewDecoder(strings.NewReader(policyText))
+	decoder.UseNumber()
+	var buffer bytes.Buffer
+	if err := copyMaskedJSON(decoder, &buffer, nil); err != nil {
+		return "", fmt.Errorf("cannot deserialize policy: %v", err)
+	}
+	return buffer.String(), nil
+}
+
+// copyMaskedJSON - copies a single JSON value from decoder to buffer, path holds object member names and [] for array elements
+func copyMaskedJSON(decoder *json.Decoder, buffer *bytes.Buffer, path []string) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	switch token := token.(type) {
+	case json.Delim:
+		buffer.WriteRune(rune(token))
+		for i := 0; decoder.More(); i++ {
+			if i > 0 {
+				buffer.WriteByte(',')
+			}
+			elementPath := append(path[:len(path):len(path)], "[]")
+			if token == '{' {
+				nameToken, err := decoder.Token()
+				if err != nil {
+					return err
+				}
+				name, _ := nameToken.(string)
+				writeJSONString(buffer, name)
+				buffer.WriteByte(':')
+				elementPath[len(path)] = name
+			}
+			if err := copyMaskedJSON(decoder, buffer, elementPath); err != nil {
+				return err
+			}
+		}
+		closing, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		buffer.WriteRune(rune(closing.(json.Delim)))
+	case string:
+		if isHookHeaderPath(path) {
+			token = maskHeader(token)
+		}
+		writeJSONString(buffer, token)
+	case json.Number:
+		buffer.WriteString(token.String())
+	case bool:
+		buffer.WriteString(strconv.FormatBool(token))
+	case nil:
+		buffer.WriteString("null")
+	}
+	return nil
+}
+
+// isHookHeaderPath - checks whether path points to a header like onSuccess[].value.headers[]
+func isHookHeaderPath(path []string) bool {
+	if len(path) < 5 {
+		return false
+	}
+	hookPath := path[len(path)-5:]
+	return (hookPath[0] == "onSuccess" || hookPath[0] == "onFailure") &&
+		hookPath[1] == "[]" && hookPath[2] == "value" && hookPath[3] == "headers" && hookPath[4] == "[]"
+}
+
+func writeJSONString(buffer *bytes.Buffer, value string) {
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.Encode(value)
+	buffer.Truncate(buffer.Len() - 1)
+}
+
+// maskHeader - keeps header whose value is made of secret references with an optional scheme like Bearer,
+// any other value including a mix of a secret reference and an inline token is masked
+func maskHeader(header string) string {
+	name, value := "", header
+	if separator := strings.Index(header, ":"); separator >= 0 {
+		name, value = header[:separator+1]+" ", header[separator+1:]
+	}
+	if secretReferencePattern.MatchString(value) && headerSchemePattern.MatchString(secretReferencePattern.ReplaceAllString(value, "")) {
+		return header
+	}
+	return name + MaskedHeaderValue
+}
@@ -0,0 +1,44 @@
+package models_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/magneticio/forklift/models"
+)
+
+const policyWithHeaders = `{"type":"release","id":1,"version":1,"onSuccess":[{"type":"http","value":{"url":"http://test.local","httpRequest":"POST","headers":["authorization: Bearer xxxyyy","x-token: ${secret:project/hooks/slack}"]}}],"onFailure":[{"type":"http","value":{"url":"http://test.local","httpRequest":"POST","headers":["x-key: a<b","x-token: ${secret:project/hooks/slack}"]}}]}`
+
+func TestFindSecretReferences(t *testing.T) {
+	policy, err := models.ParsePolicyDocument(policyWithHeaders)
+	if err != nil {
+		t.Fatalf("cannot parse policy: %v", err)
+	}
+	want := []string{"project/hooks/slack"}
+	if got := models.FindSecretReferences(*policy); !reflect.DeepEqual(got, want) {
+		t.Errorf("FindSecretReferences() = %v, want %v", got, want)
+	}
+}
+
+func TestGetSecretReferenceName(t *testing.T) {
+	if got, err := models.GetSecretReferenceName("project/hooks/slack"); err != nil || got != "hooks/slack" {
+		t.Errorf("GetSecretReferenceName() = %v, %v, want hooks/slack", got, err)
+	}
+	for _, reference := range []string{"hooks/slack", "project/", "project/../policies/1", "project/hooks//slack"} {
+		if _, err := models.GetSecretReferenceName(reference); err == nil {
+			t.Errorf("GetSecretReferenceName(%q) should fail", reference)
+		}
+	}
+}
+
+func TestMaskPolicyHeaders(t *testing.T) {
+	policy := `{"type":"release","id":1,"version":1,"metrics":[{"name":"Bearer xxxyyy","type":"TimeBased","value":{"source":"authorization: Bearer xxxyyy"}}],"onSuccess":[{"type":"http","value":{"url":"http://test.local","httpRequest":"POST","headers":["authorization: Bearer xxxyyy","x-token: ${secret:project/hooks/slack}","x-bearer: Bearer ${secret:project/hooks/slack}"]}}],"onFailure":[{"type":"http","value":{"url":"http://test.local","httpRequest":"POST","headers":["x-key: a<b","x-mixed: ${secret:project/hooks/slack} xxxyyy"]}}],"extra":{"id":18446744073709551615,"headers":["authorization: Bearer xxxyyy"],"ok":true,"none":null}}`
+	want := `{"type":"release","id":1,"version":1,"metrics":[{"name":"Bearer xxxyyy","type":"TimeBased","value":{"source":"authorization: Bearer xxxyyy"}}],"onSuccess":[{"type":"http","value":{"url":"http://test.local","httpRequest":"POST","headers":["authorization: ******","x-token: ${secret:project/hooks/slack}","x-bearer: Bearer ${secret:project/hooks/slack}"]}}],"onFailure":[{"type":"http","value":{"url":"http://test.local","httpRequest":"POST","headers":["x-key: ******","x-mixed: ******"]}}],"extra":{"id":18446744073709551615,"headers":["authorization: Bearer xxxyyy"],"ok":true,"none":null}}`
+	got, err := models.MaskPolicyHeaders(policy)
+	if err != nil {
+		t.Fatalf("MaskPolicyHeaders() error = %v", err)
+	}
+	if got != want {
+		t.Errorf("MaskPolicyHeaders() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,127 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// VersionBump - type of change between two service versions
+type VersionBump string
+
+const (
+	// InitialVersionBump - there is no previous version of the service
+	InitialVersionBump VersionBump = "initial"
+	// MajorVersionBump - major part of the version has been increased
+	MajorVersionBump VersionBump = "major"
+	// MinorVersionBump - minor part of the version has been increased
+	MinorVersionBump VersionBump = "minor"
+	// PatchVersionBump - patch part of the version has been increased
+	PatchVersionBump VersionBump = "patch"
+)
+
+// ServiceVersion - semantic version of a service
+type ServiceVersion struct {
+	Major      uint64
+	Minor      uint64
+	Patch      uint64
+	PreRelease string
+}
+
+// ParseServiceVersion - parses version in format [v]major.minor.patch[-prerelease][+metadata]
+func ParseServiceVersion(versionText string) (*ServiceVersion, error) {
+	version := strings.TrimPrefix(strings.TrimSpace(versionText), "v")
+	if idx := strings.IndexByte(version, '+'); idx >= 0 {
+		version = version[:idx]
+	}
+	preRelease := ""
+	if idx := strings.IndexByte(version, '-'); idx >= 0 {
+		preRelease = version[idx+1:]
+		version = version[:idx]
+	}
+	parts := strings.Split(version, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("version '%s' must be in format major.minor.patch", versionText)
+	}
+	numbers := make([]uint64, len(parts))
+	for i, part := range parts {
+		number, err := strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("version '%s' must be in format major.minor.patch", versionText)
+		}
+		numbers[i] = number
+	}
+
+	return &ServiceVersion{
+		Major:      numbers[0],
+		Minor:      numbers[1],
+		Patch:      numbers[2],
+		PreRelease: preRelease,
+	}, nil
+}
+
+// Compare - returns -1, 0 or 1 if version is lower, equal or greater than the other one
+func (v ServiceVersion) Compare(other ServiceVersion) int {
+	for _, pair := range [][2]uint64{{v.Major, other.Major}, {v.Minor, other.Minor}, {v.Patch, other.Patch}} {
+		if pair[0] < pair[1] {
+			return -1
+		}
+		if pair[0] > pair[1] {
+			return 1
+		}
+	}
+	switch {
+	case v.PreRelease == other.PreRelease:
+		return 0
+	case v.PreRelease == "":
+		return 1
+	case other.PreRelease == "":
+		return -1
+	case v.PreRelease < other.PreRelease:
+		return -1
+	}
+	return 1
+}
+
+func (v ServiceVersion) String() string {
+	version := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.PreRelease != "" {
+		version += "-" + v.PreRelease
+	}
+	return version
+}
+
+// GetVersionBump - gets type of change between previous and next version
+// previous version can be nil if service has not been released yet
+func GetVersionBump(previous *ServiceVersion, next ServiceVersion) (VersionBump, error) {
+	if previous == nil {
+		return InitialVersionBump, nil
+	}
+	if next.Compare(*previous) <= 0 {
+		return "", fmt.Errorf("version '%s' is not newer than version '%s'", next, previous)
+	}
+	switch {
+	case next.Major != previous.Major:
+		return MajorVersionBump, nil
+	case next.Minor != previous.Minor:
+		return MinorVersionBump, nil
+	}
+	return PatchVersionBump, nil
+}
+
+// GetLatestServiceVersion - gets the latest version from the list, versions that cannot be parsed are skipped
+func GetLatestServiceVersion(versionTexts []string) (*ServiceVersion, string) {
+	var latest *ServiceVersion
+	latestText := ""
+	for _, versionText := range versionTexts {
+		version, err := ParseServiceVersion(versionText)
+		if err != nil {
+			continue
+		}
+		if latest == nil || version.Compare(*latest) > 0 {
+			latest = version
+			latestText = versionText
+		}
+	}
+	return latest, latestText
+}
@@ -0,0 +1,156 @@
+package models_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/magneticio/forklift/models"
+)
+
+func TestGetVersionBump(t *testing.T) {
+	tests := []struct {
+		name     string
+		previous string
+		next     string
+		want     models.VersionBump
+		wantErr  error
+	}{
+		{
+			name: "no previous version",
+			next: "1.0.0",
+			want: models.InitialVersionBump,
+		},
+		{
+			name:     "patch version bump",
+			previous: "2.3.7",
+			next:     "2.3.8",
+			want:     models.PatchVersionBump,
+		},
+		{
+			name:     "minor version bump",
+			previous: "2.3.7",
+			next:     "2.4.0",
+			want:     models.MinorVersionBump,
+		},
+		{
+			name:     "major version bump with v prefix",
+			previous: "v2.3.7",
+			next:     "v3.0.0",
+			want:     models.MajorVersionBump,
+		},
+		{
+			name:     "release of a pre-release version",
+			previous: "2.4.0-rc.1",
+			next:     "2.4.0",
+			want:     models.PatchVersionBump,
+		},
+		{
+			name:     "same version",
+			previous: "2.3.7",
+			next:     "2.3.7",
+			wantErr:  errors.New("version '2.3.7' is not newer than version '2.3.7'"),
+		},
+		{
+			name:     "older version",
+			previous: "2.3.7",
+			next:     "2.2.0",
+			wantErr:  errors.New("version '2.2.0' is not newer than version '2.3.7'"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var previous *models.ServiceVersion
+			if tt.previous != "" {
+				var err error
+				previous, err = models.ParseServiceVersion(tt.previous)
+				if err != nil {
+					t.Fatalf("cannot parse previous version: %v", err)
+				}
+			}
+			next, err := models.ParseServiceVersion(tt.next)
+			if err != nil {
+				t.Fatalf("cannot parse next version: %v", err)
+			}
+			got, err := models.GetVersionBump(previous, *next)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("GetVersionBump() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("GetVersionBump() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseServiceVersionWithInvalidVersion(t *testing.T) {
+	for _, version := range []string{"", "1.0", "1.0.0.0", "a.b.c", "latest"} {
+		if _, err := models.ParseServiceVersion(version); err == nil {
+			t.Errorf("ParseServiceVersion(%q) should fail", version)
+		}
+	}
+}
+
+func TestGetLatestServiceVersion(t *testing.T) {
+	_, got := models.GetLatestServiceVersion([]string{"1.0.5", "1.10.0", "latest", "1.9.3", "1.10.0-rc.1"})
+	if got != "1.10.0" {
+		t.Errorf("GetLatestServiceVersion() = %v, want %v", got, "1.10.0")
+	}
+}
+
+func TestServiceConfigSelectPolicy(t *testing.T) {
+	tests := []struct {
+		name          string
+		serviceConfig models.ServiceConfig
+		bump          models.VersionBump
+		want          *models.PolicySelection
+	}{
+		{
+			name:          "explicit minor policy",
+			serviceConfig: validServiceConfig().build(),
+			bump:          models.MinorVersionBump,
+			want: &models.PolicySelection{
+				PolicyID: 55,
+				Slot:     "minor",
+				Explicit: true,
+				Reason:   "minor_policy_id is defined in service config",
+			},
+		},
+		{
+			name:          "default policy fallback",
+			serviceConfig: validServiceConfig().withPatchPolicyID(nil).build(),
+			bump:          models.PatchVersionBump,
+			want: &models.PolicySelection{
+				PolicyID: 33,
+				Slot:     "default",
+				Reason:   "patch_policy_id is not defined in service config, falling back to default_policy_id",
+			},
+		},
+		{
+			name:          "initial version uses default policy",
+			serviceConfig: validServiceConfig().build(),
+			bump:          models.InitialVersionBump,
+			want: &models.PolicySelection{
+				PolicyID: 33,
+				Slot:     "default",
+				Reason:   "there is no previous version, default_policy_id is used",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.serviceConfig.SelectPolicy(tt.bump)
+			if err != nil {
+				t.Fatalf("SelectPolicy() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SelectPolicy() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	_, err := validServiceConfig().withDefaultPolicyID(nil).build().SelectPolicy(models.InitialVersionBump)
+	if err == nil || err.Error() != "there is no previous version and no default policy is configured, default_policy_id is required" {
+		t.Errorf("SelectPolicy() of initial version without default policy error = %v", err)
+	}
+}
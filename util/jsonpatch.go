@@ -0,0 +1,376 @@
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const (
+	// MergePatchType - JSON merge patch as defined by RFC 7386
+	MergePatchType = "merge"
+	// JSONPatchType - JSON patch as defined by RFC 6902
+	JSONPatchType = "json"
+)
+
+type jsonPatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from"`
+	Value json.RawMessage `json:"value"`
+}
+
+// ApplyPatch - applies merge or json patch to a JSON document
+// the patched document is returned as compact JSON with sorted object keys
+func ApplyPatch(patchType string, document string, patch string) (string, error) {
+	documentValue, err := decodeJSON(document)
+	if err != nil {
+		return "", fmt.Errorf("cannot parse document: %v", err)
+	}
+
+	var patched interface{}
+	switch patchType {
+	case MergePatchType:
+		patchValue, err := decodeJSON(patch)
+		if err != nil {
+			return "", fmt.Errorf("cannot parse merge patch: %v", err)
+		}
+		patched = mergePatch(documentValue, patchValue)
+	case JSONPatchType:
+		var operations []jsonPatchOperation
+		if err := json.Unmarshal([]byte(patch), &operations); err != nil {
+			return "", fmt.Errorf("cannot parse json patch, expected an array of operations: %v", err)
+		}
+		patched = documentValue
+		for i, operation := range operations {
+			patched, err = applyPatchOperation(patched, operation)
+			if err != nil {
+				return "", fmt.Errorf("cannot apply operation %d '%s' at '%s': %v", i+1, operation.Op, operation.Path, err)
+			}
+		}
+	default:
+		return "", fmt.Errorf("unknown patch type '%s', expected %s or %s", patchType, MergePatchType, JSONPatchType)
+	}
+
+	return encodeJSON(patched, "")
+}
+
+// CanonicalJSON - formats JSON document indented with sorted object keys so that documents can be compared line by line
+func CanonicalJSON(document string) (string, error) {
+	value, err := decodeJSON(document)
+	if err != nil {
+		return "", err
+	}
+	return encodeJSON(value, "    ")
+}
+
+func decodeJSON(text string) (interface{}, error) {
+	decoder := json.NewDecoder(strings.NewReader(text))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	if decoder.More() {
+		return nil, errors.New("unexpected data after JSON value")
+	}
+	return value, nil
+}
+
+func encodeJSON(value interface{}, indent string) (string, error) {
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", indent)
+	if err := encoder.Encode(value); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buffer.String(), "\n"), nil
+}
+
+// mergePatch - RFC 7386, objects are merged recursively, null removes a member and any other value replaces the target
+func mergePatch(target interface{}, patch interface{}) interface{} {
+	patchObject, isObject := patch.(map[string]interface{})
+	if !isObject {
+		return patch
+	}
+	targetObject, isObject := target.(map[string]interface{})
+	if !isObject {
+		targetObject = make(map[string]interface{})
+	}
+	for name, value := range patchObject {
+		if value == nil {
+			delete(targetObject, name)
+		} else {
+			targetObject[name] = mergePatch(targetObject[name], value)
+		}
+	}
+	return targetObject
+}
+
+// applyPatchOperation - RFC 6902 add, remove, replace, move, copy and test operations
+func applyPatchOperation(document interface{}, operation jsonPatchOperation) (interface{}, error) {
+	path, err := parseJSONPointer(operation.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch operation.Op {
+	case "add", "replace", "test":
+		if operation.Value == nil {
+			return nil, errors.New("value is required")
+		}
+		value, err := decodeJSON(string(operation.Value))
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse value: %v", err)
+		}
+		switch operation.Op {
+		case "add":
+			return addJSONValue(document, path, value)
+		case "replace":
+			return replaceJSONValue(document, path, value)
+		}
+		current, err := getJSONValue(document, path)
+		if err != nil {
+			return nil, err
+		}
+		if !equalJSONValues(current, value) {
+			return nil, errors.New("test failed, values differ")
+		}
+		return document, nil
+	case "remove":
+		return removeJSONValue(document, path)
+	case "move", "copy":
+		from, err := parseJSONPointer(operation.From)
+		if err != nil {
+			return nil, err
+		}
+		value, err := getJSONValue(document, from)
+		if err != nil {
+			return nil, fmt.Errorf("cannot get '%s': %v", operation.From, err)
+		}
+		if operation.Op == "copy" {
+			return addJSONValue(document, path, copyJSONValue(value))
+		}
+		if operation.Path == operation.From {
+			return document, nil
+		}
+		if strings.HasPrefix(operation.Path, operation.From+"/") {
+			return nil, fmt.Errorf("cannot move '%s' into one of its children", operation.From)
+		}
+		document, err = removeJSONValue(document, from)
+		if err != nil {
+			return nil, err
+		}
+		return addJSONValue(document, path, value)
+	}
+	return nil, errors.New("unknown operation, expected add, remove, replace, move, copy or test")
+}
+
+func parseJSONPointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return []string{}, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("invalid JSON pointer '%s', expected it to start with '/'", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.Replace(strings.Replace(token, "~1", "/", -1), "~0", "~", -1)
+	}
+	return tokens, nil
+}
+
+func getJSONValue(document interface{}, path []string) (interface{}, error) {
+	value := document
+	for _, token := range path {
+		switch container := value.(type) {
+		case map[string]interface{}:
+			member, exists := container[token]
+			if !exists {
+				return nil, fmt.Errorf("member '%s' does not exist", token)
+			}
+			value = member
+		case []interface{}:
+			index, err := parseArrayIndex(token, len(container)-1)
+			if err != nil {
+				return nil, err
+			}
+			value = container[index]
+		default:
+			return nil, fmt.Errorf("cannot get '%s' of a value which is neither an object nor an array", token)
+		}
+	}
+	return value, nil
+}
+
+func addJSONValue(document interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	return updateJSONContainer(document, path, func(container interface{}, token string) (interface{}, error) {
+		switch container := container.(type) {
+		case map[string]interface{}:
+			container[token] = value
+			return container, nil
+		case []interface{}:
+			if token == "-" {
+				return append(container, value), nil
+			}
+			index, err := parseArrayIndex(token, len(container))
+			if err != nil {
+				return nil, err
+			}
+			container = append(container, nil)
+			copy(container[index+1:], container[index:])
+			container[index] = value
+			return container, nil
+		}
+		return nil, fmt.Errorf("cannot add '%s' to a value which is neither an object nor an array", token)
+	})
+}
+
+func removeJSONValue(document interface{}, path []string) (interface{}, error) {
+	if len(path) == 0 {
+		return nil, errors.New("cannot remove the whole document")
+	}
+	return updateJSONContainer(document, path, func(container interface{}, token string) (interface{}, error) {
+		switch container := container.(type) {
+		case map[string]interface{}:
+			if _, exists := container[token]; !exists {
+				return nil, fmt.Errorf("member '%s' does not exist", token)
+			}
+			delete(container, token)
+			return container, nil
+		case []interface{}:
+			index, err := parseArrayIndex(token, len(container)-1)
+			if err != nil {
+				return nil, err
+			}
+			return append(container[:index], container[index+1:]...), nil
+		}
+		return nil, fmt.Errorf("cannot remove '%s' from a value which is neither an object nor an array", token)
+	})
+}
+
+func replaceJSONValue(document interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	return updateJSONContainer(document, path, func(container interface{}, token string) (interface{}, error) {
+		switch container := container.(type) {
+		case map[string]interface{}:
+			if _, exists := container[token]; !exists {
+				return nil, fmt.Errorf("member '%s' does not exist", token)
+			}
+			container[token] = value
+			return container, nil
+		case []interface{}:
+			index, err := parseArrayIndex(token, len(container)-1)
+			if err != nil {
+				return nil, err
+			}
+			container[index] = value
+			return container, nil
+		}
+		return nil, fmt.Errorf("cannot replace '%s' of a value which is neither an object nor an array", token)
+	})
+}
+
+// updateJSONContainer - applies update to the container of the last path token
+// containers are replaced on the way back because arrays can be reallocated by the update
+func updateJSONContainer(document interface{}, path []string, update func(container interface{}, token string) (interface{}, error)) (interface{}, error) {
+	if len(path) == 1 {
+		return update(document, path[0])
+	}
+	child, err := getJSONValue(document, path[:1])
+	if err != nil {
+		return nil, err
+	}
+	updatedChild, err := updateJSONContainer(child, path[1:], update)
+	if err != nil {
+		return nil, err
+	}
+	switch container := document.(type) {
+	case map[string]interface{}:
+		container[path[0]] = updatedChild
+	case []interface{}:
+		index, _ := parseArrayIndex(path[0], len(container)-1)
+		container[index] = updatedChild
+	}
+	return document, nil
+}
+
+func parseArrayIndex(token string, maxIndex int) (int, error) {
+	if token == "" || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("invalid array index '%s'", token)
+	}
+	index, err := strconv.Atoi(token)
+	if err != nil || index < 0 {
+		return 0, fmt.Errorf("invalid array index '%s'", token)
+	}
+	if index > maxIndex {
+		return 0, fmt.Errorf("array index '%d' is out of bounds", index)
+	}
+	return index, nil
+}
+
+func copyJSONValue(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(value))
+		for name, member := range value {
+			copied[name] = copyJSONValue(member)
+		}
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(value))
+		for i, element := range value {
+			copied[i] = copyJSONValue(element)
+		}
+		return copied
+	}
+	return value
+}
+
+// equalJSONValues - compares values structurally, numbers are compared by value so 1 and 1.0 are equal
+func equalJSONValues(a interface{}, b interface{}) bool {
+	switch a := a.(type) {
+	case json.Number:
+		b, isNumber := b.(json.Number)
+		if !isNumber {
+			return false
+		}
+		aFloat, aErr := a.Float64()
+		bFloat, bErr := b.Float64()
+		return aErr == nil && bErr == nil && aFloat == bFloat
+	case map[string]interface{}:
+		b, isObject := b.(map[string]interface{})
+		if !isObject || len(a) != len(b) {
+			return false
+		}
+		for name, member := range a {
+			other, exists := b[name]
+			if !exists || !equalJSONValues(member, other) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		b, isArray := b.([]interface{})
+		if !isArray || len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if !equalJSONValues(a[i], b[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,104 @@
+package util_test
+
+import (
+	"testing"
+
+	"github.com/magneticio/forklift/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyMergePatch(t *testing.T) {
+	document := `{"title":"Goodbye!","author":{"givenName":"John","familyName":"Doe"},"tags":["example","sample"],"content":"This will be unchanged"}`
+	patch := `{"title":"Hello!","phoneNumber":"+01-123-456-7890","author":{"familyName":null},"tags":["example"]}`
+
+	result, err := util.ApplyPatch(util.MergePatchType, document, patch)
+
+	assert.Nil(t, err)
+	assert.Equal(t, `{"author":{"givenName":"John"},"content":"This will be unchanged","phoneNumber":"+01-123-456-7890","tags":["example"],"title":"Hello!"}`, result)
+}
+
+func TestApplyJSONPatch(t *testing.T) {
+	document := `{"application_id":18446744073709551615,"ingress_rules":[{"domain":"a.local","port":80}],"k8s_labels":{"app":"shop"}}`
+	patch := `[
+		{"op":"test","path":"/ingress_rules/0/port","value":80.0},
+		{"op":"replace","path":"/ingress_rules/0/port","value":8080},
+		{"op":"add","path":"/ingress_rules/-","value":{"domain":"b.local","port":81}},
+		{"op":"copy","from":"/ingress_rules/0","path":"/ingress_rules/0"},
+		{"op":"remove","path":"/ingress_rules/1"},
+		{"op":"move","from":"/k8s_labels/app","path":"/k8s_labels/app.kubernetes.io~1name"}
+	]`
+
+	result, err := util.ApplyPatch(util.JSONPatchType, document, patch)
+
+	assert.Nil(t, err)
+	assert.Equal(t, `{"application_id":18446744073709551615,"ingress_rules":[{"domain":"a.local","port":8080},{"domain":"b.local","port":81}],"k8s_labels":{"app.kubernetes.io/name":"shop"}}`, result)
+
+	_, err = util.ApplyPatch(util.JSONPatchType, document, `[{"op":"test","path":"/k8s_labels/app","value":"web"}]`)
+	assert.EqualError(t, err, "cannot apply operation 1 'test' at '/k8s_labels/app': test failed, values differ")
+
+	_, err = util.ApplyPatch(util.JSONPatchType, document, `[{"op":"replace","path":"/ingress_rules/1/port","value":1}]`)
+	assert.EqualError(t, err, "cannot apply operation 1 'replace' at '/ingress_rules/1/port': array index '1' is out of bounds")
+
+	_, err = util.ApplyPatch(util.JSONPatchType, document, `[{"op":"remove","path":"/headless"}]`)
+	assert.EqualError(t, err, "cannot apply operation 1 'remove' at '/headless': member 'headless' does not exist")
+
+	_, err = util.ApplyPatch("strategic", document, `{}`)
+	assert.EqualError(t, err, "unknown patch type 'strategic', expected merge or json")
+}
+
+func TestApplyJSONPatchEdgeCases(t *testing.T) {
+	document := `{"a/b":1,"m~n":2,"list":[1,2],"object":{"x":1,"y":[true,null]}}`
+
+	tests := []struct {
+		patch string
+		want  string
+	}{
+		{`[{"op":"add","path":"/list/-","value":3}]`, `{"a/b":1,"list":[1,2,3],"m~n":2,"object":{"x":1,"y":[true,null]}}`},
+		{`[{"op":"add","path":"/object/y/-","value":{"z":null}}]`, `{"a/b":1,"list":[1,2],"m~n":2,"object":{"x":1,"y":[true,null,{"z":null}]}}`},
+		{`[{"op":"replace","path":"/a~1b","value":3},{"op":"remove","path":"/m~0n"}]`, `{"a/b":3,"list":[1,2],"object":{"x":1,"y":[true,null]}}`},
+		{`[{"op":"add","path":"/~01","value":"tilde one"}]`, `{"a/b":1,"list":[1,2],"m~n":2,"object":{"x":1,"y":[true,null]},"~1":"tilde one"}`},
+		{`[{"op":"add","path":"/","value":"empty name"}]`, `{"":"empty name","a/b":1,"list":[1,2],"m~n":2,"object":{"x":1,"y":[true,null]}}`},
+		{`[{"op":"add","path":"/list/1","value":null}]`, `{"a/b":1,"list":[1,null,2],"m~n":2,"object":{"x":1,"y":[true,null]}}`},
+		{`[{"op":"test","path":"/object","value":{"y":[true,null],"x":1.0}}]`, document},
+		{`[{"op":"test","path":"","value":{"object":{"x":1,"y":[true,null]},"list":[1,2],"m~n":2,"a/b":1}}]`, document},
+	}
+	for _, test := range tests {
+		result, err := util.ApplyPatch(util.JSONPatchType, document, test.patch)
+		if assert.Nil(t, err, test.patch) {
+			want, _ := util.ApplyPatch(util.MergePatchType, test.want, `{}`)
+			assert.Equal(t, want, result, test.patch)
+		}
+	}
+
+	failing := []struct {
+		patch string
+		err   string
+	}{
+		{`[{"op":"replace","path":"/list/-","value":3}]`, "cannot apply operation 1 'replace' at '/list/-': invalid array index '-'"},
+		{`[{"op":"remove","path":"/list/-"}]`, "cannot apply operation 1 'remove' at '/list/-': invalid array index '-'"},
+		{`[{"op":"test","path":"/list/-","value":2}]`, "cannot apply operation 1 'test' at '/list/-': invalid array index '-'"},
+		{`[{"op":"add","path":"/list/01","value":3}]`, "cannot apply operation 1 'add' at '/list/01': invalid array index '01'"},
+		{`[{"op":"test","path":"/object","value":{"x":1}}]`, "cannot apply operation 1 'test' at '/object': test failed, values differ"},
+		{`[{"op":"test","path":"/object","value":{"x":1,"y":[null,true]}}]`, "cannot apply operation 1 'test' at '/object': test failed, values differ"},
+		{`[{"op":"test","path":"/object/x","value":"1"}]`, "cannot apply operation 1 'test' at '/object/x': test failed, values differ"},
+	}
+	for _, test := range failing {
+		_, err := util.ApplyPatch(util.JSONPatchType, document, test.patch)
+		assert.EqualError(t, err, test.err, test.patch)
+	}
+}
+
+func TestDiffLines(t *testing.T) {
+	from := "{\n    \"port\": 80,\n    \"path\": \"/\"\n}\n"
+	to := "{\n    \"port\": 8080,\n    \"path\": \"/\",\n    \"tls\": true\n}\n"
+
+	assert.Equal(t, []string{
+		"  {",
+		"-     \"port\": 80,",
+		"-     \"path\": \"/\"",
+		"+     \"port\": 8080,",
+		"+     \"path\": \"/\",",
+		"+     \"tls\": true",
+		"  }",
+	}, util.DiffLines(from, to))
+}
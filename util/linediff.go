@@ -0,0 +1,45 @@
+package util
+
+import "strings"
+
+// DiffLines - compares two texts line by line using the longest common subsequence
+// removed lines are prefixed with "- ", added lines with "+ " and unchanged lines with "  "
+func DiffLines(from string, to string) []string {
+	fromLines := strings.Split(strings.TrimSuffix(from, "\n"), "\n")
+	toLines := strings.Split(strings.TrimSuffix(to, "\n"), "\n")
+
+	// common[i][j] - length of the longest common subsequence of fromLines[i:] and toLines[j:]
+	common := make([][]int, len(fromLines)+1)
+	for i := range common {
+		common[i] = make([]int, len(toLines)+1)
+	}
+	for i := len(fromLines) - 1; i >= 0; i-- {
+		for j := len(toLines) - 1; j >= 0; j-- {
+			if fromLines[i] == toLines[j] {
+				common[i][j] = common[i+1][j+1] + 1
+			} else if common[i+1][j] >= common[i][j+1] {
+				common[i][j] = common[i+1][j]
+			} else {
+				common[i][j] = common[i][j+1]
+			}
+		}
+	}
+
+	diff := make([]string, 0, len(fromLines)+len(toLines))
+	i, j := 0, 0
+	for i < len(fromLines) || j < len(toLines) {
+		switch {
+		case i < len(fromLines) && j < len(toLines) && fromLines[i] == toLines[j]:
+			diff = append(diff, "  "+fromLines[i])
+			i++
+			j++
+		case j >= len(toLines) || (i < len(fromLines) && common[i+1][j] >= common[i][j+1]):
+			diff = append(diff, "- "+fromLines[i])
+			i++
+		default:
+			diff = append(diff, "+ "+toLines[j])
+			j++
+		}
+	}
+	return diff
+}
@@ -1,6 +1,7 @@
 package util
 
 import (
+	"bufio"
 	"bytes"
 	"crypto/sha1"
 	"crypto/sha512"
@@ -201,6 +202,19 @@ func GetParameterFromTerminalAsSecret(text1 string, text2 string, errorText stri
 	return input1, nil
 }
 
+// Confirm - asks a yes or no question on the terminal, anything but y or yes is treated as no
+func Confirm(question string) (bool, error) {
+	fmt.Printf("%s [y/N]: ", question)
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err == io.EOF {
+		fmt.Println()
+	} else if err != nil {
+		return false, err
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes", nil
+}
+
 var src = rand.NewSource(time.Now().UnixNano())
 
 const letterBytes = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"